@@ -0,0 +1,82 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"os"
+	"time"
+)
+
+type actionKind int
+
+const (
+	actionLog actionKind = iota
+	actionPanic
+	actionExit
+)
+
+// Action is what Panic and Fatal do after logging their entry
+type Action struct {
+	kind     actionKind
+	exitCode int
+}
+
+// ActionLog only logs the entry
+func ActionLog() Action {
+	return Action{kind: actionLog}
+}
+
+// ActionPanic panics with the message after logging the entry
+func ActionPanic() Action {
+	return Action{kind: actionPanic}
+}
+
+// ActionExit flushes and syncs the log files after logging the entry, then
+// exits the process with code. Deferred functions do not run.
+func ActionExit(code int) Action {
+	return Action{kind: actionExit, exitCode: code}
+}
+
+// WithPanicAction sets what Panic and the other Panic level methods do after
+// logging. The default is ActionLog, since Panic has always only logged at
+// Panic level and existing callers do not expect it to unwind; use
+// ActionPanic for the behavior of the standard library's log.Panic.
+func WithPanicAction(action Action) Option {
+	return func(l *Logger) {
+		l.panicAction = action
+	}
+}
+
+// WithFatalAction sets what Fatal and Fatalf do after logging, ActionExit(1) by
+// default. ActionLog keeps tests and libraries from killing the process.
+func WithFatalAction(action Action) Option {
+	return func(l *Logger) {
+		l.fatalAction = action
+	}
+}
+
+// Fatal logs message at Panic level, then runs the fatal action, see WithFatalAction
+func (l *Logger) Fatal(message string) {
+	l.emit(time.Time{}, PanicLevel, message, nil, nil)
+	l.run(l.fatalAction, message)
+}
+
+// Fatalf formats and logs a message at Panic level, then runs the fatal action
+func (l *Logger) Fatalf(format string, args ...interface{}) {
+	l.Fatal(l.sprintf(format, args...))
+}
+
+// run carries out action for an entry logged with message
+func (l *Logger) run(action Action, message string) {
+	switch action.kind {
+	case actionPanic:
+		panic(message)
+	case actionExit:
+		l.outMu.Lock()
+		l.syncFiles(PanicLevel)
+		l.outMu.Unlock()
+		os.Exit(action.exitCode)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// expectPanic calls fn and fails the test unless it panics with message
+func expectPanic(t *testing.T, message string, fn func()) {
+	t.Helper()
+	defer func() {
+		if r := recover(); r != message {
+			t.Errorf("recovered %v, want %q", r, message)
+		}
+	}()
+	fn()
+}
+
+func TestPanicActionDefault(t *testing.T) {
+	logger, out := newTestLogger()
+	logger.Panic("logged only")
+	logger.Panicf("count: %d", 3)
+	if got := lines(out); len(got) != 2 || !strings.Contains(got[0], "PANI") {
+		t.Errorf("got lines %q, want both Panic entries logged", got)
+	}
+}
+
+func TestPanicActionPanic(t *testing.T) {
+	logger, out := newTestLogger(WithPanicAction(ActionPanic()))
+	expectPanic(t, "boom", func() { logger.Panic("boom") })
+	expectPanic(t, "count: 3", func() { logger.Panicf("count: %d", 3) })
+	expectPanic(t, "line", func() { logger.Panicln("line") })
+	if got := lines(out); len(got) != 3 {
+		t.Errorf("got lines %q, want each entry logged before panicking", got)
+	}
+
+	// Derived loggers keep the action
+	expectPanic(t, "derived", func() { logger.AddField("k", "v").Panic("derived") })
+}
+
+func TestFatalActionLog(t *testing.T) {
+	logger, out := newTestLogger(WithFatalAction(ActionLog()))
+	logger.Fatal("still running")
+	logger.Fatalf("code %d", 2)
+	if got := lines(out); len(got) != 2 || !strings.Contains(got[1], "PANI") || !strings.HasSuffix(got[1], "code 2") {
+		t.Errorf("got lines %q, want both Fatal entries at Panic level", got)
+	}
+}
+
+func TestFatalActionPanic(t *testing.T) {
+	logger, _ := newTestLogger(WithFatalAction(ActionPanic()))
+	expectPanic(t, "fatal", func() { logger.Fatal("fatal") })
+}
+
+func TestFatalActionExit(t *testing.T) {
+	if path := os.Getenv("TROLOG_FATAL_FILE"); path != "" {
+		logger := New(WithFile(path), WithOutput(nil), WithFileBuffer(4096, 0), WithFatalAction(ActionExit(3)))
+		logger.Fatal("exiting")
+		return
+	}
+
+	path := filepath.Join(t.TempDir(), "fatal.log")
+	cmd := exec.Command(os.Args[0], "-test.run=^TestFatalActionExit$")
+	cmd.Env = append(os.Environ(), "TROLOG_FATAL_FILE="+path)
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 3 {
+		t.Fatalf("process ended with %v, want exit code 3", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "exiting") {
+		t.Errorf("buffered entry was not flushed before exiting, file = %q", data)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"io"
+	"sync"
+)
+
+// StripANSI returns a writer that removes ANSI escape sequences before writing
+// to w. It keeps a file clean when colored output is shared with the terminal,
+// e.g. io.MultiWriter(os.Stdout, trolog.StripANSI(file)).
+func StripANSI(w io.Writer) io.Writer {
+	return &ansiStripper{w: w}
+}
+
+// ansiStripper removes ANSI escape sequences from everything written through it.
+// Sequences split across writes are handled.
+type ansiStripper struct {
+	w     io.Writer
+	mu    sync.Mutex
+	state int // 0 text, 1 after ESC, 2 inside a CSI sequence
+}
+
+func (s *ansiStripper) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]byte, 0, len(p))
+	for _, c := range p {
+		switch s.state {
+		case 0:
+			if c == 0x1b {
+				s.state = 1
+				continue
+			}
+			out = append(out, c)
+		case 1:
+			if c == '[' {
+				s.state = 2
+			} else {
+				s.state = 0 // Two byte sequence such as ESC c, drop both
+			}
+		case 2:
+			if c >= 0x40 && c <= 0x7e { // Final byte of the sequence
+				s.state = 0
+			}
+		}
+	}
+
+	if _, err := s.w.Write(out); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
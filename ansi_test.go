@@ -0,0 +1,45 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestStripANSI(t *testing.T) {
+	var out bytes.Buffer
+	w := StripANSI(&out)
+	input := "\033[33mWARN\033[0m \033[1;31mbold red\033[0m \033cplain"
+	if n, err := w.Write([]byte(input)); err != nil || n != len(input) {
+		t.Fatalf("Write = %d, %v, want %d, nil", n, err, len(input))
+	}
+	if got, want := out.String(), "WARN bold red plain"; got != want {
+		t.Errorf("stripped %q, want %q", got, want)
+	}
+}
+
+func TestStripANSISplitWrites(t *testing.T) {
+	var out bytes.Buffer
+	w := StripANSI(&out)
+	input := "\033[33mWARN\033[0m done\n"
+	for i := 0; i < len(input); i++ {
+		_, _ = w.Write([]byte{input[i]})
+	}
+	if got := out.String(); got != "WARN done\n" {
+		t.Errorf("stripped %q byte by byte, want %q", got, "WARN done\n")
+	}
+}
+
+func TestStripANSILogger(t *testing.T) {
+	var out bytes.Buffer
+	logger := New(WithOutput(StripANSI(&out)), WithColor(true))
+	logger.SetFieldColor("k", "\033[35m")
+	logger.AddField("k", "v").Error("failed")
+	if strings.ContainsRune(out.String(), 0x1b) {
+		t.Errorf("output %q still contains escape sequences", out.String())
+	}
+}
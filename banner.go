@@ -0,0 +1,34 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"os"
+	"runtime/debug"
+	"strconv"
+)
+
+// bannerMessage is the message of the line written by WithBanner
+const bannerMessage = "logger started"
+
+// WithBanner writes a "logger started" entry when the logger is created, so a
+// log file begins with where it came from. The entry carries the hostname, pid,
+// start time and, when built as a module, the main module version, plus the
+// given fields, which take precedence.
+func WithBanner(fields map[string]string) Option {
+	return func(l *Logger) {
+		banner := map[string]string{
+			"hostname": getHostname(),
+			"pid":      strconv.Itoa(os.Getpid()),
+		}
+		if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+			banner["version"] = info.Main.Version
+		}
+		for k, v := range fields {
+			banner[k] = v
+		}
+		l.banner = banner
+	}
+}
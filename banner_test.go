@@ -0,0 +1,51 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestWithBanner(t *testing.T) {
+	logger, out := newTestLogger(WithBanner(map[string]string{"app": "api", "hostname": "web-1"}))
+	logger.AddField("k", "v").Info("first")
+	logger.Info("second")
+
+	got := lines(out)
+	if len(got) != 3 {
+		t.Fatalf("lines = %q, want the banner and two entries", got)
+	}
+	if n := strings.Count(out.String(), bannerMessage); n != 1 {
+		t.Errorf("banner written %d times", n)
+	}
+	entry, err := Parse(got[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"app":      "api",
+		"hostname": "web-1",
+		"pid":      strconv.Itoa(os.Getpid()),
+		"start":    "2024-10-10T10:24:44Z",
+	}
+	if entry.Message != bannerMessage || entry.Level != InfoLevel {
+		t.Errorf("banner = %q at %v", entry.Message, entry.Level)
+	}
+	for k, v := range want {
+		if entry.Fields[k] != v {
+			t.Errorf("banner field %s = %q, want %q", k, entry.Fields[k], v)
+		}
+	}
+
+	// Without the option there is no banner
+	logger, out = newTestLogger()
+	logger.Info("only")
+	if strings.Contains(out.String(), bannerMessage) {
+		t.Errorf("unexpected banner in %q", out.String())
+	}
+}
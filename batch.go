@@ -0,0 +1,81 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"sync"
+	"time"
+)
+
+// Batch collects entries and writes them together on Commit, so entries from
+// other goroutines cannot end up in between, e.g. for a multi-line dump
+type Batch struct {
+	l       *Logger
+	mu      sync.Mutex
+	entries []batchEntry
+}
+
+type batchEntry struct {
+	level       LogLevel
+	message     string
+	extraFields map[string]string // caller fields, taken when the entry is added
+}
+
+// Batch returns an empty batch that writes through this logger
+func (l *Logger) Batch() *Batch {
+	return &Batch{l: l}
+}
+
+func (b *Batch) add(level LogLevel, message string) {
+	extraFields := b.l.addCaller(level, nil)
+	b.mu.Lock()
+	b.entries = append(b.entries, batchEntry{level: level, message: message, extraFields: extraFields})
+	b.mu.Unlock()
+}
+
+// Log methods for different levels, entries are held until Commit
+func (b *Batch) Info(message string)  { b.add(InfoLevel, message) }
+func (b *Batch) Warn(message string)  { b.add(WarnLevel, message) }
+func (b *Batch) Error(message string) { b.add(ErrorLevel, message) }
+func (b *Batch) Panic(message string) { b.add(PanicLevel, message) }
+func (b *Batch) Debug(message string) { b.add(DebugLevel, message) }
+func (b *Batch) Trace(message string) { b.add(TraceLevel, message) }
+
+// Commit writes the collected entries as one contiguous block and empties the batch.
+// Log IDs and timestamps are assigned at commit time. Entries are sampled like
+// any other, and the panic action runs for Panic entries once the block is written.
+func (b *Batch) Commit() {
+	b.mu.Lock()
+	entries := b.entries
+	b.entries = nil
+	b.mu.Unlock()
+
+	if len(entries) == 0 {
+		return
+	}
+
+	buf := bufferPool.Get().(*[]byte)
+	defer bufferPool.Put(buf)
+	sc := getScratch()
+	defer scratchPool.Put(sc)
+
+	b.l.outMu.Lock()
+	for _, e := range entries {
+		if !b.l.keep(e.level) {
+			continue
+		}
+		*buf = (*buf)[:0]
+		sc.arena = sc.arena[:0]
+		rec := b.l.prepare(time.Time{}, e.level, e.message, e.extraFields, nil, buf, sc)
+		b.l.write(&rec)
+	}
+	b.l.outMu.Unlock()
+
+	for _, e := range entries {
+		if e.level == PanicLevel {
+			b.l.run(b.l.panicAction, e.message)
+		}
+	}
+}
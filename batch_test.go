@@ -0,0 +1,98 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestBatchContiguous(t *testing.T) {
+	logger, out := newTestLogger()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				logger.Info("noise")
+				b := logger.Batch()
+				for j := 0; j < 5; j++ {
+					b.Info(fmt.Sprintf("batch %d.%d line %d", g, i, j))
+				}
+				b.Commit()
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	got := lines(out)
+	if len(got) != 8*20*6 {
+		t.Fatalf("wrote %d lines, want %d", len(got), 8*20*6)
+	}
+	for i, line := range got {
+		if !strings.HasSuffix(line, " line 0") {
+			continue
+		}
+		name := line[strings.Index(line, "batch "):strings.Index(line, " line 0")]
+		for j := 1; j < 5; j++ {
+			if i+j >= len(got) || !strings.HasSuffix(got[i+j], name+" line "+fmt.Sprint(j)) {
+				t.Fatalf("%s was interrupted at line %d: %q", name, j, got[i:min(i+5, len(got))])
+			}
+		}
+	}
+}
+
+func TestBatchSamplingAndCaller(t *testing.T) {
+	logger, out := newTestLogger(WithSampling(map[LogLevel]int{InfoLevel: 2}), WithCaller(CallerFile))
+	b := logger.Batch()
+	for i := 0; i < 4; i++ {
+		b.Info("sampled")
+	}
+	b.Warn("kept")
+	b.Commit()
+
+	got := lines(out)
+	if len(got) != 3 {
+		t.Fatalf("got lines %q, want 2 of 4 info entries and the warning", got)
+	}
+	for _, line := range got {
+		if !strings.Contains(line, `caller: "batch_test.go:`) {
+			t.Errorf("line %q does not have the caller of the batch method", line)
+		}
+	}
+}
+
+func TestBatchDiscard(t *testing.T) {
+	logger := New(WithOutput(io.Discard))
+	b := logger.Batch()
+	b.Info("one")
+	b.Commit()
+	if got := logger.Stats()[InfoLevel]; got != 1 {
+		t.Errorf("Stats()[InfoLevel] = %d, want 1", got)
+	}
+}
+
+func TestBatchPanicAction(t *testing.T) {
+	logger, out := newTestLogger(WithPanicAction(ActionPanic()))
+	b := logger.Batch()
+	b.Panic("broken")
+	b.Info("after")
+
+	defer func() {
+		if r := recover(); r != "broken" {
+			t.Errorf("recovered %v, want the panic message", r)
+		}
+		if got := lines(out); len(got) != 2 {
+			t.Errorf("got lines %q, want the whole batch written before panicking", got)
+		}
+	}()
+	b.Commit()
+	t.Error("Commit did not panic")
+}
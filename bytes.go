@@ -0,0 +1,67 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import "strconv"
+
+// ByteUnits selects how AddBytes writes byte counts
+type ByteUnits int
+
+const (
+	BytesIEC   ByteUnits = iota // base 1024: 1.5 KiB, 2.3 MiB
+	BytesSI                     // base 1000: 1.5 kB, 2.3 MB
+	BytesJEDEC                  // base 1024: 1.5 KB, 2.3 MB
+)
+
+var byteUnitNames = [...][6]string{
+	BytesIEC:   {"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"},
+	BytesSI:    {"kB", "MB", "GB", "TB", "PB", "EB"},
+	BytesJEDEC: {"KB", "MB", "GB", "TB", "PB", "EB"},
+}
+
+// WithByteUnits sets the units used by AddBytes, BytesIEC by default
+func WithByteUnits(units ByteUnits) Option {
+	return func(l *Logger) {
+		if units >= BytesIEC && units <= BytesJEDEC {
+			l.byteUnits = units
+		}
+	}
+}
+
+// AddBytes adds a field holding the byte count n in a readable form, such as
+// "1.5 KiB", and returns a new logger instance. Counts below one unit are
+// written in bytes, e.g. "1023 B".
+func (l *Logger) AddBytes(key string, n int64) *Logger {
+	key, ok := l.fieldKey(key)
+	if !ok {
+		return l
+	}
+	return l.withField(key, formatBytes(n, l.byteUnits))
+}
+
+// formatBytes formats n with one decimal in the largest unit it reaches
+func formatBytes(n int64, units ByteUnits) string {
+	base := 1024.0
+	if units == BytesSI {
+		base = 1000
+	}
+
+	sign := ""
+	value := float64(n)
+	if n < 0 {
+		sign = "-"
+		value = -value
+	}
+	if value < base {
+		return strconv.FormatInt(n, 10) + " B"
+	}
+
+	unit := -1
+	for value >= base && unit < len(byteUnitNames[units])-1 {
+		value /= base
+		unit++
+	}
+	return sign + strconv.FormatFloat(value, 'f', 1, 64) + " " + byteUnitNames[units][unit]
+}
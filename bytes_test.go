@@ -0,0 +1,53 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n     int64
+		units ByteUnits
+		want  string
+	}{
+		{0, BytesIEC, "0 B"},
+		{1023, BytesIEC, "1023 B"},
+		{1024, BytesIEC, "1.0 KiB"},
+		{1536, BytesIEC, "1.5 KiB"},
+		{1048576, BytesIEC, "1.0 MiB"},
+		{-2048, BytesIEC, "-2.0 KiB"},
+		{999, BytesSI, "999 B"},
+		{1000, BytesSI, "1.0 kB"},
+		{1023, BytesSI, "1.0 kB"},
+		{1024, BytesSI, "1.0 kB"},
+		{1048576, BytesSI, "1.0 MB"},
+		{2300000, BytesSI, "2.3 MB"},
+		{1024, BytesJEDEC, "1.0 KB"},
+		{1048576, BytesJEDEC, "1.0 MB"},
+		{1 << 62, BytesIEC, "4.0 EiB"},
+	}
+	for _, tt := range tests {
+		if got := formatBytes(tt.n, tt.units); got != tt.want {
+			t.Errorf("formatBytes(%d, %d) = %q, want %q", tt.n, tt.units, got, tt.want)
+		}
+	}
+}
+
+func TestAddBytes(t *testing.T) {
+	logger, out := newTestLogger()
+	logger.AddBytes("size", 1536).Info("default units")
+	New(WithOutput(out), WithByteUnits(BytesSI)).AddBytes("size", 1536).Info("SI units")
+	New(WithOutput(out), WithByteUnits(ByteUnits(9))).AddBytes("size", 1024).Info("invalid units")
+
+	got := lines(out)
+	for i, want := range []string{`size: "1.5 KiB"`, `size: "1.5 kB"`, `size: "1.0 KiB"`} {
+		if !strings.HasSuffix(got[i], want) {
+			t.Errorf("line %q, want suffix %q", got[i], want)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// CallerMode selects which parts of the code location logging an entry are added as fields
+type CallerMode int
+
+const (
+	CallerOff  CallerMode = iota
+	CallerFile            // caller: "main.go:42"
+	CallerFunc            // func: "github.com/you/app/server.(*Server).handle"
+	CallerBoth            // caller and func
+)
+
+// maxCallerDepth bounds the stack walked to find the first frame outside trolog
+const maxCallerDepth = 16
+
+// packagePrefix is the function name prefix of this package, "github.com/.../trolog."
+var packagePrefix = func() string {
+	pc, _, _, _ := runtime.Caller(0)
+	name := runtime.FuncForPC(pc).Name()
+	slash := strings.LastIndexByte(name, '/')
+	return name[:slash+strings.IndexByte(name[slash+1:], '.')+2]
+}()
+
+// WithCaller adds the location of the code that logged each entry as fields:
+// the file name and line as "caller", the function name as "func", or both.
+// Walking the stack has a cost, so CallerFile or CallerFunc alone keep it lower
+// where one is enough.
+func WithCaller(mode CallerMode) Option {
+	return func(l *Logger) {
+		l.caller = mode
+	}
+}
+
+// WithShortCallerFunc trims the import path from the function name added by
+// WithCaller, writing "server.(*Server).handle" rather than
+// "github.com/you/app/server.(*Server).handle"
+func WithShortCallerFunc(short bool) Option {
+	return func(l *Logger) {
+		l.shortCallerFunc = short
+	}
+}
+
+// WithTraceCaller sets the caller fields added to Trace entries when WithCaller
+// is off, CallerFile by default since trace output is mostly read next to the code
+func WithTraceCaller(mode CallerMode) Option {
+	return func(l *Logger) {
+		l.traceCaller = mode
+	}
+}
+
+// withCaller returns extraFields with the caller fields of mode added, copying it if needed
+func (l *Logger) withCaller(mode CallerMode, extraFields map[string]string) map[string]string {
+	var pcs [maxCallerDepth]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs[:])])
+	for {
+		frame, more := frames.Next()
+		// Test files of this package count as callers so its tests see their own lines
+		if !strings.HasPrefix(frame.Function, packagePrefix) || strings.HasSuffix(frame.File, "_test.go") {
+			fields := make(map[string]string, len(extraFields)+2)
+			for k, v := range extraFields {
+				fields[k] = v
+			}
+			if mode == CallerFile || mode == CallerBoth {
+				fields["caller"] = filepath.Base(frame.File) + ":" + strconv.Itoa(frame.Line)
+			}
+			if mode == CallerFunc || mode == CallerBoth {
+				fields["func"] = l.callerFunc(frame.Function)
+			}
+			return fields
+		}
+		if !more {
+			return extraFields
+		}
+	}
+}
+
+// callerFunc returns the function name as added by WithCaller
+func (l *Logger) callerFunc(name string) string {
+	if l.shortCallerFunc {
+		return name[strings.LastIndexByte(name, '/')+1:]
+	}
+	return name
+}
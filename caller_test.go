@@ -0,0 +1,86 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestWithCaller(t *testing.T) {
+	tests := []struct {
+		opts       []Option
+		caller, fn bool
+		wantFunc   string
+	}{
+		{[]Option{WithCaller(CallerOff)}, false, false, ""},
+		{[]Option{WithCaller(CallerFile)}, true, false, ""},
+		{[]Option{WithCaller(CallerFunc)}, false, true, "github.com/mdtolhabinashraf/trolog.TestWithCaller"},
+		{[]Option{WithCaller(CallerBoth), WithShortCallerFunc(true)}, true, true, "trolog.TestWithCaller"},
+	}
+	for _, tt := range tests {
+		logger, out := newTestLogger(tt.opts...)
+		_, _, line, _ := runtime.Caller(0)
+		logger.Info("here")
+
+		entry, err := Parse(lines(out)[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		caller, hasCaller := entry.Fields["caller"]
+		fn, hasFunc := entry.Fields["func"]
+		if hasCaller != tt.caller || hasFunc != tt.fn {
+			t.Errorf("fields %v, want caller %v and func %v", entry.Fields, tt.caller, tt.fn)
+		}
+		if wantCaller := "caller_test.go:" + strconv.Itoa(line+1); tt.caller && caller != wantCaller {
+			t.Errorf("caller = %q, want %q", caller, wantCaller)
+		}
+		if fn != tt.wantFunc {
+			t.Errorf("func = %q, want %q", fn, tt.wantFunc)
+		}
+	}
+}
+
+func TestTraceCaller(t *testing.T) {
+	logger, out := newTestLogger(WithLevel(DebugLevel))
+	logger.Trace("traced")
+	logger.Debug("not traced")
+	silent, silentOut := newTestLogger(WithLevel(DebugLevel), WithTraceCaller(CallerOff))
+	silent.Trace("no caller")
+
+	if entry, _ := Parse(lines(out)[0]); entry.Fields["caller"] == "" {
+		t.Errorf("Trace entry %q has no caller", lines(out)[0])
+	}
+	if entry, _ := Parse(lines(out)[1]); len(entry.Fields) != 0 {
+		t.Errorf("Debug entry %q has fields", lines(out)[1])
+	}
+	if entry, _ := Parse(lines(silentOut)[0]); len(entry.Fields) != 0 {
+		t.Errorf("Trace entry %q has a caller with WithTraceCaller(CallerOff)", lines(silentOut)[0])
+	}
+}
+
+func TestTraceColor(t *testing.T) {
+	var out bytes.Buffer
+	logger := New(WithOutput(&out), WithLevel(DebugLevel), WithColor(true))
+	logger.Trace("dim")
+
+	line := out.String()
+	if !strings.Contains(line, "\033[90mTRAC\033[0m") {
+		t.Errorf("line %q, want a gray TRAC label", line)
+	}
+	if !strings.Contains(line, `caller: "caller_test.go:`) {
+		t.Errorf("line %q, want the caller", line)
+	}
+
+	out.Reset()
+	logger = New(WithOutput(&out), WithLevel(DebugLevel), WithColor(true), WithLevelColor(TraceLevel, "\033[35m"))
+	logger.Trace("magenta")
+	if !strings.Contains(out.String(), "\033[35mTRAC\033[0m") {
+		t.Errorf("line %q, want the overridden color", out.String())
+	}
+}
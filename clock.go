@@ -0,0 +1,34 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import "time"
+
+// Clock tells the time to a logger, e.g. a fixed time in tests
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the default Clock, reading the system time
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// WithClock makes the logger take the time of entries, of the banner and the
+// start of elapsed timestamps from c instead of the system clock, so tests get
+// deterministic output. Background timers such as file flushing and the
+// network sinks keep using the system clock. A nil c keeps the system clock.
+func WithClock(c Clock) Option {
+	return func(l *Logger) {
+		if c != nil {
+			l.clock = c
+		}
+	}
+}
+
+// Now returns the current time of the logger's clock, see WithClock
+func (l *Logger) Now() time.Time {
+	return l.clock.Now()
+}
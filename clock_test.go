@@ -0,0 +1,81 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// stepClock is a Clock that advances by step on every call after the first
+type stepClock struct {
+	t    time.Time
+	step time.Duration
+	n    int
+}
+
+func (c *stepClock) Now() time.Time {
+	t := c.t.Add(time.Duration(c.n) * c.step)
+	c.n++
+	return t
+}
+
+func TestWithClock(t *testing.T) {
+	logger, out := newTestLogger()
+	logger.Info("one")
+	logger.AddField("k", "v").Info("two")
+
+	for _, line := range lines(out) {
+		if !strings.Contains(line, " 2024-10-10T10:24:44Z ") {
+			t.Errorf("line %q is not timestamped by the clock", line)
+		}
+	}
+	if got := logger.Now(); !got.Equal(testTime) {
+		t.Errorf("Now() = %v, want %v", got, testTime)
+	}
+}
+
+func TestWithClockFormats(t *testing.T) {
+	logger, out := newTestLogger(WithFormat(FormatJSON))
+	logger.Info("json")
+	if !strings.Contains(out.String(), `"time":"2024-10-10T10:24:44Z"`) {
+		t.Errorf("JSON line %q is not timestamped by the clock", out.String())
+	}
+
+	logger, out = newTestLogger(WithTimeFormat(TimeUnixMilli))
+	logger.Info("epoch")
+	if want := " 1728555884000 "; !strings.Contains(out.String(), want) {
+		t.Errorf("line %q does not contain %q", out.String(), want)
+	}
+}
+
+func TestWithClockElapsed(t *testing.T) {
+	var out bytes.Buffer
+	clock := &stepClock{t: testTime, step: 1500 * time.Millisecond}
+	logger := New(WithOutput(StripANSI(&out)), WithClock(clock), WithElapsedTime(time.Millisecond))
+	logger.Info("first")
+	logger.Info("second")
+
+	got := lines(&out)
+	if len(got) != 2 || !strings.Contains(got[0], " +1.5s first") || !strings.Contains(got[1], " +3s second") {
+		t.Errorf("got lines %q, want elapsed times from the clock", got)
+	}
+}
+
+func TestWithClockBanner(t *testing.T) {
+	_, out := newTestLogger(WithBanner(nil))
+	if !strings.Contains(out.String(), `start: "2024-10-10T10:24:44Z"`) {
+		t.Errorf("banner %q does not start at the clock's time", out.String())
+	}
+}
+
+func TestWithClockNil(t *testing.T) {
+	logger := New(WithOutput(&bytes.Buffer{}), WithClock(nil))
+	if since := time.Since(logger.Now()); since < 0 || since > time.Minute {
+		t.Errorf("Now() is %v away from the system time", since)
+	}
+}
@@ -0,0 +1,14 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+//go:build !windows
+
+package trolog
+
+import "io"
+
+// prepareColorOutput returns w unchanged, terminals outside Windows handle ANSI escapes
+func prepareColorOutput(w io.Writer) io.Writer {
+	return w
+}
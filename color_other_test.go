@@ -0,0 +1,18 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+//go:build !windows
+
+package trolog
+
+import (
+	"os"
+	"testing"
+)
+
+func TestPrepareColorOutput(t *testing.T) {
+	if w := prepareColorOutput(os.Stdout); w != os.Stdout {
+		t.Errorf("prepareColorOutput wrapped stdout: %T", w)
+	}
+}
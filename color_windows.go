@@ -0,0 +1,42 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+//go:build windows
+
+package trolog
+
+import (
+	"io"
+	"os"
+	"syscall"
+)
+
+const enableVirtualTerminalProcessing = 0x0004
+
+var procSetConsoleMode = syscall.NewLazyDLL("kernel32.dll").NewProc("SetConsoleMode")
+
+// prepareColorOutput enables ANSI escape processing when w is a Windows console.
+// If the console does not support it, w is wrapped to strip escape sequences
+// instead of printing them as garbage.
+func prepareColorOutput(w io.Writer) io.Writer {
+	f, ok := w.(*os.File)
+	if !ok {
+		return w
+	}
+
+	handle := syscall.Handle(f.Fd())
+	var mode uint32
+	if err := syscall.GetConsoleMode(handle, &mode); err != nil {
+		return w // Not a console, e.g. redirected to a file
+	}
+	if mode&enableVirtualTerminalProcessing != 0 {
+		return w
+	}
+
+	r, _, _ := procSetConsoleMode.Call(uintptr(handle), uintptr(mode|enableVirtualTerminalProcessing))
+	if r == 0 {
+		return &ansiStripper{w: w}
+	}
+	return w
+}
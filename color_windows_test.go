@@ -0,0 +1,47 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+//go:build windows
+
+package trolog
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+func TestPrepareColorOutputNotConsole(t *testing.T) {
+	var buf bytes.Buffer
+	if w := prepareColorOutput(&buf); w != &buf {
+		t.Errorf("prepareColorOutput wrapped a buffer: %T", w)
+	}
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "out.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if w := prepareColorOutput(f); w != f {
+		t.Errorf("prepareColorOutput wrapped a file: %T", w)
+	}
+}
+
+func TestPrepareColorOutputConsole(t *testing.T) {
+	var mode uint32
+	if err := syscall.GetConsoleMode(syscall.Handle(os.Stdout.Fd()), &mode); err != nil {
+		t.Skip("stdout is not a console")
+	}
+
+	// Colors must either be processed by the console or stripped, never printed raw
+	w := prepareColorOutput(os.Stdout)
+	if _, stripped := w.(*ansiStripper); stripped {
+		return
+	}
+	if err := syscall.GetConsoleMode(syscall.Handle(os.Stdout.Fd()), &mode); err != nil || mode&enableVirtualTerminalProcessing == 0 {
+		t.Errorf("console mode %#x without virtual terminal processing, and output is not stripped", mode)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Config holds logger settings in a form that can be decoded from JSON or
+// built from environment variables and passed to NewFromConfig in one shot.
+// Zero values fall back to the same defaults as New.
+type Config struct {
+	Level      string    `json:"level"`       // debug, info, warn, error, panic or trace
+	Output     io.Writer `json:"-"`           // terminal output, os.Stdout when nil
+	Colored    bool      `json:"colored"`     // colored terminal output, text format only
+	ColorFile  bool      `json:"color_file"`  // colored log file, text format only
+	FilePath   string    `json:"file_path"`   // log file, disabled when empty
+	Format     string    `json:"format"`      // text, json, gelf or csv
+	TimeFormat string    `json:"time_format"` // time.Format layout or epoch format, RFC3339 when empty
+	Recent     int       `json:"recent"`      // number of entries kept in memory, see Recent
+}
+
+// NewFromConfig validates cfg and creates a logger from it. Unlike NewLogger,
+// an invalid setting or a log file that cannot be opened is reported as an error.
+func NewFromConfig(cfg Config) (*Logger, error) {
+	opts, err := cfg.options()
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.FilePath != "" {
+		logFile, err := openLogFile(cfg.FilePath)
+		if err != nil {
+			return nil, fmt.Errorf("trolog: opening log file: %w", err)
+		}
+		opts = append(opts, func(l *Logger) { l.file = logFile })
+	}
+
+	return New(opts...), nil
+}
+
+// MustNewFromConfig is like NewFromConfig but panics if cfg is invalid or the
+// log file cannot be opened, for programs that should fail fast at startup
+func MustNewFromConfig(cfg Config) *Logger {
+	l, err := NewFromConfig(cfg)
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
+
+// options validates the config and converts it to options, excluding the log file
+func (cfg Config) options() ([]Option, error) {
+	var opts []Option
+
+	if cfg.Level != "" {
+		level, ok := lookupLogLevel(cfg.Level)
+		if !ok {
+			return nil, errors.New("trolog: unknown level " + strconv.Quote(cfg.Level))
+		}
+		opts = append(opts, WithLevel(level))
+	}
+
+	format := FormatText
+	switch cfg.Format {
+	case "", "text":
+	case "json":
+		format = FormatJSON
+	case "gelf":
+		format = FormatGELF
+	case "csv":
+		format = FormatCSV
+	default:
+		return nil, errors.New("trolog: unknown format " + strconv.Quote(cfg.Format))
+	}
+	if cfg.Colored && format != FormatText {
+		return nil, errors.New("trolog: colored output is only supported by the text format")
+	}
+	if cfg.ColorFile && format != FormatText {
+		return nil, errors.New("trolog: colored files are only supported by the text format")
+	}
+	opts = append(opts, WithFormat(format), WithColor(cfg.Colored), WithColorFile(cfg.ColorFile))
+
+	if cfg.Recent < 0 {
+		return nil, errors.New("trolog: recent must not be negative")
+	}
+	if cfg.Recent > 0 {
+		opts = append(opts, WithRecent(cfg.Recent))
+	}
+
+	if cfg.Output != nil {
+		opts = append(opts, WithOutput(cfg.Output))
+	}
+	if cfg.TimeFormat != "" {
+		opts = append(opts, WithTimeFormat(cfg.TimeFormat))
+	}
+	return opts, nil
+}
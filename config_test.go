@@ -0,0 +1,83 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestNewFromConfig(t *testing.T) {
+	var out bytes.Buffer
+	path := filepath.Join(t.TempDir(), "app.log")
+	var cfg Config
+	if err := json.Unmarshal([]byte(`{"level":"warn","format":"json","file_path":`+strconv.Quote(path)+`,"recent":2}`), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	cfg.Output = &out
+
+	logger, err := NewFromConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.Info("hidden")
+	logger.Warn("shown")
+	_ = logger.Close()
+
+	if got := out.String(); strings.Contains(got, "hidden") || !strings.Contains(got, `"msg":"shown"`) {
+		t.Errorf("output = %q, want only the JSON warning", got)
+	}
+	if data, err := os.ReadFile(path); err != nil || !strings.Contains(string(data), `"msg":"shown"`) {
+		t.Errorf("file = %q, %v", data, err)
+	}
+	if len(logger.Recent()) != 2 {
+		t.Errorf("Recent() = %v, want 2 entries", logger.Recent())
+	}
+}
+
+func TestNewFromConfigInvalid(t *testing.T) {
+	for _, cfg := range []Config{
+		{Level: "loud"},
+		{Format: "yaml"},
+		{Format: "json", Colored: true},
+		{Format: "csv", ColorFile: true},
+		{Recent: -1},
+		{FilePath: filepath.Join(t.TempDir(), "missing", "app.log")},
+	} {
+		if logger, err := NewFromConfig(cfg); err == nil || logger != nil {
+			t.Errorf("NewFromConfig(%+v) = %v, %v, want an error", cfg, logger, err)
+		} else if !strings.HasPrefix(err.Error(), "trolog: ") {
+			t.Errorf("error %q is not prefixed with trolog:", err)
+		}
+	}
+}
+
+func TestMustNewFromConfig(t *testing.T) {
+	for _, path := range []string{t.TempDir(), filepath.Join(t.TempDir(), "missing", "app.log")} {
+		func() {
+			defer func() {
+				err, ok := recover().(error)
+				if !ok || !strings.HasPrefix(err.Error(), "trolog: opening log file: ") {
+					t.Errorf("path %q: recovered %v, want the open error", path, err)
+				}
+			}()
+			MustNewFromConfig(Config{FilePath: path})
+		}()
+	}
+
+	var out bytes.Buffer
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger := MustNewFromConfig(Config{Level: "debug", Output: &out, FilePath: path})
+	logger.Debug("started")
+	_ = logger.Close()
+	if data, err := os.ReadFile(path); err != nil || !strings.Contains(string(data), "started") || !strings.Contains(out.String(), "started") {
+		t.Errorf("output = %q, file = %q, %v", out.String(), data, err)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import "strconv"
+
+const csvHeader = "id,level,time,message,fields\n"
+
+// appendCSVMessage appends a log message encoded as a CSV row to dst. Fields are
+// serialized as a JSON object in the last column, which is empty when there are none.
+func appendCSVMessage(dst []byte, enc *encoding, level LogLevel, timestamp, message string, fields []field, extraFields map[string]string, logID int32) []byte {
+	dst = strconv.AppendInt(dst, int64(logID), 10)
+	dst = append(dst, ',')
+	dst = appendCSVValue(dst, enc.label(level))
+	dst = append(dst, ',')
+	dst = appendCSVValue(dst, timestamp)
+	dst = append(dst, ',')
+	dst = appendCSVValue(dst, message)
+	dst = append(dst, ',')
+
+	if len(fields) > 0 || len(extraFields) > 0 {
+		var obj []byte
+		for _, f := range fields {
+			key, value := f.key, f.value
+			obj = appendJSONField(obj, key, value)
+		}
+		for key, value := range extraFields {
+			obj = appendJSONField(obj, key, value)
+		}
+		obj[0] = '{' // Replace the leading comma added by appendJSONField
+		obj = append(obj, '}')
+		dst = appendCSVValue(dst, string(obj))
+	}
+
+	dst = append(dst, '\n')
+	return dst
+}
+
+// appendCSVValue appends s to dst, quoted per RFC 4180 if it contains a comma,
+// a double quote, a line break or leading/trailing space
+func appendCSVValue(dst []byte, s string) []byte {
+	if !csvNeedsQuotes(s) {
+		return append(dst, s...)
+	}
+
+	dst = append(dst, '"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' {
+			dst = append(dst, '"')
+		}
+		dst = append(dst, s[i])
+	}
+	return append(dst, '"')
+}
+
+func csvNeedsQuotes(s string) bool {
+	if s == "" {
+		return false
+	}
+	if s[0] == ' ' || s[len(s)-1] == ' ' {
+		return true
+	}
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case ',', '"', '\r', '\n':
+			return true
+		}
+	}
+	return false
+}
+
+// writeCSVHeader writes the CSV header row to the output and to an empty log file
+func (l *Logger) writeCSVHeader() {
+	header := withLineEnding([]byte(csvHeader), l.lineEnding)
+	if l.output != nil {
+		_, _ = l.output.Write(header)
+	}
+	if l.file != nil {
+		if info, err := l.file.Stat(); err == nil && info.Size() == 0 {
+			_ = l.writeFileLine(header)
+		}
+	}
+}
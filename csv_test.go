@@ -0,0 +1,55 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestCSV(t *testing.T) {
+	logger, out := newTestLogger(WithFormat(FormatCSV), WithCSVHeader())
+	messages := []string{"plain", "a, b", `say "hi"`, "two\nlines", " padded "}
+	for _, message := range messages {
+		logger.AddField("note", `x,"y"`).Warn(message)
+	}
+	logger.Info("no fields")
+
+	records, err := csv.NewReader(strings.NewReader(out.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("output %q is not CSV: %v", out.String(), err)
+	}
+	if len(records) != len(messages)+2 {
+		t.Fatalf("read %d records, want the header and %d rows", len(records), len(messages)+1)
+	}
+	if got := strings.Join(records[0], ","); got != "id,level,time,message,fields" {
+		t.Errorf("header = %q", got)
+	}
+
+	for i, message := range messages {
+		row := records[i+1]
+		if row[1] != "WARN" || row[2] != "2024-10-10T10:24:44Z" || row[3] != message {
+			t.Errorf("row %q, want WARN, the test time and message %q", row, message)
+		}
+		var fields map[string]string
+		if err := json.Unmarshal([]byte(row[4]), &fields); err != nil || fields["note"] != `x,"y"` {
+			t.Errorf("fields column %q = %v, %v", row[4], fields, err)
+		}
+	}
+	if last := records[len(records)-1]; last[3] != "no fields" || last[4] != "" {
+		t.Errorf("row %q, want an empty fields column", last)
+	}
+}
+
+func TestCSVHeaderOnce(t *testing.T) {
+	logger, out := newTestLogger(WithFormat(FormatCSV), WithCSVHeader())
+	logger.AddField("k", "v").Info("one")
+	logger.Info("two")
+	if n := strings.Count(out.String(), "id,level,time,message,fields"); n != 1 {
+		t.Errorf("header written %d times, want once:\n%s", n, out.String())
+	}
+}
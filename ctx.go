@@ -0,0 +1,83 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// ContextExtractor returns fields to attach to an entry logged with ctx,
+// e.g. a request or trace ID stored in the context
+type ContextExtractor func(ctx context.Context) map[string]string
+
+// WithContextExtractor registers fn to add fields to entries logged with the
+// Ctx methods. Extractors run in order; later ones win on duplicate keys.
+func WithContextExtractor(fn ContextExtractor) Option {
+	return func(l *Logger) {
+		l.extractors = append(l.extractors, fn)
+	}
+}
+
+// WithDropCanceled drops entries logged with a Ctx method whose context is
+// already canceled or past its deadline, so no work is spent on abandoned
+// requests. Dropped entries are counted, see CanceledDrops and Drops.
+func WithDropCanceled(drop bool) Option {
+	return func(l *Logger) {
+		l.dropCanceled = drop
+	}
+}
+
+// CanceledDrops returns the number of entries dropped for a done context, see WithDropCanceled
+func (l *Logger) CanceledDrops() uint64 {
+	return atomic.LoadUint64(&l.stats.canceled)
+}
+
+// logCtx logs with the fields of ctx, unless ctx is done and such entries are dropped
+func (l *Logger) logCtx(ctx context.Context, level LogLevel, message string) {
+	if l.dropCanceled && ctx != nil && ctx.Err() != nil {
+		atomic.AddUint64(&l.stats.canceled, 1)
+		return
+	}
+	l.log(level, message, l.contextFields(ctx))
+}
+
+// contextFields collects the fields from all extractors for ctx
+func (l *Logger) contextFields(ctx context.Context) map[string]string {
+	if ctx == nil || len(l.extractors) == 0 {
+		return nil
+	}
+
+	var fields map[string]string
+	for _, extract := range l.extractors {
+		for k, v := range extract(ctx) {
+			if fields == nil {
+				fields = make(map[string]string)
+			}
+			fields[k] = v
+		}
+	}
+	return fields
+}
+
+// Log methods for different levels, adding fields from the registered context extractors
+func (l *Logger) InfoCtx(ctx context.Context, message string) {
+	l.logCtx(ctx, InfoLevel, message)
+}
+func (l *Logger) WarnCtx(ctx context.Context, message string) {
+	l.logCtx(ctx, WarnLevel, message)
+}
+func (l *Logger) ErrorCtx(ctx context.Context, message string) {
+	l.logCtx(ctx, ErrorLevel, message)
+}
+func (l *Logger) PanicCtx(ctx context.Context, message string) {
+	l.logCtx(ctx, PanicLevel, message)
+}
+func (l *Logger) DebugCtx(ctx context.Context, message string) {
+	l.logCtx(ctx, DebugLevel, message)
+}
+func (l *Logger) TraceCtx(ctx context.Context, message string) {
+	l.logCtx(ctx, TraceLevel, message)
+}
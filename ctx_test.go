@@ -0,0 +1,61 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+type requestIDKey struct{}
+
+func TestContextExtractor(t *testing.T) {
+	logger, out := newTestLogger(
+		WithContextExtractor(func(ctx context.Context) map[string]string {
+			if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+				return map[string]string{"request_id": id}
+			}
+			return nil
+		}),
+	)
+	logger.InfoCtx(context.WithValue(context.Background(), requestIDKey{}, "r1"), "with id")
+	logger.InfoCtx(context.Background(), "without id")
+
+	got := lines(out)
+	if !strings.HasSuffix(got[0], `with id, request_id: "r1"`) || !strings.HasSuffix(got[1], "without id") {
+		t.Errorf("lines = %q", got)
+	}
+}
+
+func TestDropCanceled(t *testing.T) {
+	logger, out := newTestLogger(WithDropCanceled(true))
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancel := context.WithDeadline(context.Background(), time.Unix(0, 0))
+	defer cancel()
+
+	logger.InfoCtx(context.Background(), "live")
+	logger.InfoCtx(canceled, "canceled")
+	logger.AddField("k", "v").ErrorCtx(expired, "expired")
+
+	if got := lines(out); len(got) != 1 || !strings.HasSuffix(got[0], "live") {
+		t.Errorf("lines = %q, want only the live entry", got)
+	}
+	if n := logger.CanceledDrops(); n != 2 {
+		t.Errorf("CanceledDrops() = %d, want 2", n)
+	}
+	if d := logger.Drops(); d.Canceled != 2 || d.Total() != 2 {
+		t.Errorf("Drops() = %+v, want 2 canceled", d)
+	}
+
+	// Without the option a done context is logged as usual
+	logger, out = newTestLogger()
+	logger.InfoCtx(canceled, "still logged")
+	if len(lines(out)) != 1 || logger.CanceledDrops() != 0 {
+		t.Errorf("lines = %q, drops = %d", lines(out), logger.CanceledDrops())
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Drops counts the entries that were not written, by cause
+type Drops struct {
+	Sampled  uint64 // sampled out, see WithSampling
+	Canceled uint64 // logged with a done context, see WithDropCanceled
+	Sinks    uint64 // not delivered by a network, syslog or webhook sink
+}
+
+// Total returns the number of dropped entries of all causes
+func (d Drops) Total() uint64 {
+	return d.Sampled + d.Canceled + d.Sinks
+}
+
+// dropCounter is implemented by sinks that drop entries rather than block
+type dropCounter interface {
+	droppedEntries() uint64
+}
+
+// Drops returns the number of entries dropped so far by the logger and its
+// derived loggers, by cause
+func (l *Logger) Drops() Drops {
+	d := Drops{
+		Sampled:  atomic.LoadUint64(&l.stats.sampled),
+		Canceled: atomic.LoadUint64(&l.stats.canceled),
+	}
+	for _, s := range l.sinks {
+		if c, ok := s.(dropCounter); ok {
+			d.Sinks += c.droppedEntries()
+		}
+	}
+	return d
+}
+
+// Dropped returns the total number of entries dropped so far, see Drops
+func (l *Logger) Dropped() uint64 {
+	return l.Drops().Total()
+}
+
+// dropReporter periodically logs how many entries were dropped since the last report
+type dropReporter struct {
+	interval time.Duration
+	l        *Logger
+	last     Drops
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// WithDropReport logs a warning such as "dropped 42 log entries" every interval
+// (one minute when not positive) in which entries were dropped, with the counts
+// by cause as fields, so that silent loss shows up in the logs themselves. The
+// report stops after Close or Shutdown.
+func WithDropReport(interval time.Duration) Option {
+	return func(l *Logger) {
+		if interval <= 0 {
+			interval = time.Minute
+		}
+		l.dropReporter = &dropReporter{
+			interval: interval,
+			l:        l,
+			stop:     make(chan struct{}),
+			done:     make(chan struct{}),
+		}
+	}
+}
+
+// run reports drops on every tick until stopped
+func (r *dropReporter) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			r.report()
+		case <-r.stop:
+			return
+		}
+	}
+}
+
+// report logs the drops since the last report, if any
+func (r *dropReporter) report() {
+	now := r.l.Drops()
+	delta := Drops{
+		Sampled:  now.Sampled - r.last.Sampled,
+		Canceled: now.Canceled - r.last.Canceled,
+		Sinks:    now.Sinks - r.last.Sinks,
+	}
+	r.last = now
+	if delta.Total() == 0 {
+		return
+	}
+
+	r.l.Log(WarnLevel, "dropped "+strconv.FormatUint(delta.Total(), 10)+" log entries",
+		Uint64("sampled", delta.Sampled),
+		Uint64("canceled", delta.Canceled),
+		Uint64("sinks", delta.Sinks),
+	)
+	// A sink that is still full drops the report too; not counting that keeps
+	// the report from repeating itself every interval. Only the sink count is
+	// moved on, so drops of other causes in the meantime are still reported.
+	r.last.Sinks += r.l.Drops().Sinks - now.Sinks
+}
+
+// Close stops reporting
+func (r *dropReporter) Close() error {
+	r.once.Do(func() { close(r.stop) })
+	<-r.done
+	return nil
+}
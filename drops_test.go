@@ -0,0 +1,110 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"io"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// queueSink is a sink with a bounded queue nobody drains, dropping entries once it is full
+type queueSink struct {
+	queue   chan []byte
+	dropped atomic.Uint64
+}
+
+func (s *queueSink) write(entry Entry, line []byte) {
+	select {
+	case s.queue <- line:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+func (s *queueSink) Close() error { return nil }
+
+func (s *queueSink) droppedEntries() uint64 { return s.dropped.Load() }
+
+func TestDropsOverflow(t *testing.T) {
+	s := &queueSink{queue: make(chan []byte, 2)}
+	logger := New(WithOutput(io.Discard), withSink(func() sink { return s }))
+	defer logger.Close()
+
+	for i := 0; i < 5; i++ {
+		logger.Info("burst")
+	}
+	if d := logger.Drops(); d.Sinks != 3 || logger.Dropped() != 3 {
+		t.Errorf("Drops() = %+v, Dropped() = %d, want 3 sink drops", d, logger.Dropped())
+	}
+	logger.AddField("k", "v").Info("derived")
+	if n := logger.Dropped(); n != 4 {
+		t.Errorf("Dropped() = %d after a derived logger overflowed, want 4", n)
+	}
+}
+
+func TestDropReport(t *testing.T) {
+	s := &queueSink{queue: make(chan []byte, 2)}
+	logger, out := newTestLogger(WithDropReport(time.Hour), withSink(func() sink { return s }))
+	defer logger.Close()
+
+	logger.dropReporter.report()
+	for i := 0; i < 5; i++ {
+		logger.Info("burst")
+	}
+	logger.dropReporter.report()
+	logger.dropReporter.report()
+
+	got := lines(out)
+	if len(got) != 6 {
+		t.Fatalf("lines = %q, want the entries and one report", got)
+	}
+	if want := `WARN 2024-10-10T10:24:44Z dropped 3 log entries, sampled: "0" canceled: "0" sinks: "3"`; !strings.HasSuffix(got[5], want) {
+		t.Errorf("report %q, want suffix %q", got[5], want)
+	}
+	// The report overflowed the sink as well, which is counted but not reported in turn
+	if n := logger.Dropped(); n != 4 {
+		t.Errorf("Dropped() = %d, want 4", n)
+	}
+}
+
+// cancelSink counts an entry dropped for a done context on every write, as if
+// another goroutine dropped one while the entry was written
+type cancelSink struct {
+	l *Logger
+}
+
+func (s *cancelSink) write(entry Entry, line []byte) {
+	atomic.AddUint64(&s.l.stats.canceled, 1)
+}
+
+func (s *cancelSink) Close() error { return nil }
+
+func TestDropReportKeepsConcurrentDrops(t *testing.T) {
+	s := &queueSink{queue: make(chan []byte)}
+	c := &cancelSink{}
+	logger, out := newTestLogger(WithDropReport(time.Hour), withSink(func() sink { return s }), withSink(func() sink { return c }))
+	defer logger.Close()
+	c.l = logger
+
+	atomic.AddUint64(&logger.stats.sampled, 2)
+	logger.dropReporter.report()
+	logger.dropReporter.report()
+
+	got := lines(out)
+	if len(got) != 2 {
+		t.Fatalf("lines = %q, want two reports", got)
+	}
+	if want := `dropped 2 log entries, sampled: "2" canceled: "0" sinks: "0"`; !strings.HasSuffix(got[0], want) {
+		t.Errorf("report %q, want suffix %q", got[0], want)
+	}
+	// The canceled entry counted while the first report was written is in the
+	// second one; the sink drop of the report itself is not
+	if want := `dropped 1 log entries, sampled: "0" canceled: "1" sinks: "0"`; !strings.HasSuffix(got[1], want) {
+		t.Errorf("report %q, want suffix %q", got[1], want)
+	}
+}
@@ -0,0 +1,354 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"strconv"
+	"strings"
+)
+
+// encoding holds the settings shared by the built-in encoders
+type encoding struct {
+	labels         [TraceLevel + 1]string // labels as written, after applying labelCase
+	baseLabels     [TraceLevel + 1]string
+	labelCase      LabelCase
+	layout         []layoutSegment
+	prefix         string             // written with a space before the message
+	fieldSep       string             // written between the message and the fields
+	idWidth        int                // IDs are zero-padded to this many digits
+	severity       func(LogLevel) int // numeric severity in JSON, see WithSeverity
+	severityKey    string
+	severityOnly   bool // the severity replaces the level label
+	lineColor      bool // color whole terminal lines, see WithLineColor
+	errorHighlight bool // only highlight errors, see WithErrorHighlight
+	colors         [TraceLevel + 1]string
+	epochTime      bool     // timestamps are epoch integers, unquoted in JSON
+	groups         []string // field groups, nested in JSON, see Logger.Group
+	quote          QuoteStyle
+}
+
+// defaultEncoding is used where no logger settings apply, e.g. by TextFormatter
+var defaultEncoding = newEncoding()
+
+func newEncoding() encoding {
+	var e encoding
+	copy(e.baseLabels[:], logLevelStrings[:])
+	e.labels = e.baseLabels
+	e.layout = parseLayout(DefaultLayout)
+	e.fieldSep = ","
+	for level := range e.colors {
+		e.colors[level] = getColor(LogLevel(level))
+	}
+	return e
+}
+
+// label returns the label written for level, UNKNOWN for a level outside DebugLevel to TraceLevel
+func (e *encoding) label(level LogLevel) string {
+	if level < DebugLevel || level > TraceLevel {
+		return applyLabelCase(logLevelStrings[len(logLevelStrings)-1], e.labelCase)
+	}
+	return e.labels[level]
+}
+
+// colorsLine reports whether the level color of a terminal line runs to the end
+// of the line rather than being reset after the label, as it does for all but
+// DEBU, INFO and TRAC lines. Lines are never colored with WithErrorHighlight.
+func (e *encoding) colorsLine(level LogLevel) bool {
+	if e.errorHighlight {
+		return false
+	}
+	return e.lineColor || level != DebugLevel && level != InfoLevel && level != TraceLevel
+}
+
+// color returns the ANSI color of level on the terminal
+func (e *encoding) color(level LogLevel) string {
+	if level < DebugLevel || level > TraceLevel {
+		return getColor(level)
+	}
+	return e.colors[level]
+}
+
+// WithLevelColor sets the ANSI escape sequence level is colored with on the
+// terminal, e.g. "\033[35m" for magenta. An empty color leaves it uncolored.
+func WithLevelColor(level LogLevel, color string) Option {
+	return func(l *Logger) {
+		if level >= DebugLevel && level <= TraceLevel {
+			l.enc.colors[level] = color
+		}
+	}
+}
+
+// WithLineColor colors whole terminal lines, from the ID to the last field, in
+// the color of their level rather than only the level label, with a single
+// reset at the end of each line
+func WithLineColor(lineColor bool) Option {
+	return func(l *Logger) {
+		l.enc.lineColor = lineColor
+	}
+}
+
+// errorColor is the color errors are highlighted in with WithErrorHighlight
+const errorColor = "\033[31m"
+
+// WithErrorHighlight leaves terminal lines plain, level labels included, but
+// highlights the message of ERRO and PANI lines and the value of "error"
+// fields in red, to draw the eye to problems only. It takes precedence over
+// WithLineColor and does not affect the log files.
+func WithErrorHighlight(enabled bool) Option {
+	return func(l *Logger) {
+		l.enc.errorHighlight = enabled
+	}
+}
+
+// highlights reports whether the message of a level is highlighted as an error
+func (e *encoding) highlights(level LogLevel) bool {
+	return e.errorHighlight && (level == ErrorLevel || level == PanicLevel)
+}
+
+// updateLabels recomputes the written labels from the base labels and case
+func (e *encoding) updateLabels() {
+	for i, label := range e.baseLabels {
+		e.labels[i] = applyLabelCase(label, e.labelCase)
+	}
+}
+
+// LabelPreset selects a built-in set of level labels. Each preset uses labels
+// of equal width so columns stay aligned.
+type LabelPreset int
+
+const (
+	LabelsDefault LabelPreset = iota // DEBU INFO WARN ERRO PANI TRAC
+	LabelsShort                      // D I W E P T
+)
+
+var shortLabels = [TraceLevel + 1]string{"D", "I", "W", "E", "P", "T"}
+
+// WithLabelPreset selects the level labels used in every output. It combines
+// with WithLabelCase.
+func WithLabelPreset(preset LabelPreset) Option {
+	return func(l *Logger) {
+		switch preset {
+		case LabelsShort:
+			l.enc.baseLabels = shortLabels
+		default:
+			l.enc.baseLabels = defaultEncoding.baseLabels
+		}
+		l.enc.updateLabels()
+	}
+}
+
+// LabelCase controls the letter case of level labels
+type LabelCase int
+
+const (
+	LabelUpper LabelCase = iota // INFO
+	LabelLower                  // info
+	LabelTitle                  // Info
+)
+
+// WithLabelCase sets the letter case of level labels in every output
+func WithLabelCase(labelCase LabelCase) Option {
+	return func(l *Logger) {
+		l.enc.labelCase = labelCase
+		l.enc.updateLabels()
+	}
+}
+
+func applyLabelCase(label string, labelCase LabelCase) string {
+	switch labelCase {
+	case LabelLower:
+		return strings.ToLower(label)
+	case LabelTitle:
+		if label == "" {
+			return label
+		}
+		return strings.ToUpper(label[:1]) + strings.ToLower(label[1:])
+	default:
+		return strings.ToUpper(label)
+	}
+}
+
+// Layouts for WithLayout
+const (
+	// DefaultLayout is the layout of the text format
+	DefaultLayout = "ID:{id} {level} {time} {msg}"
+
+	// MessageFirstLayout starts lines with the message, for alerting tools that
+	// expect it first, and brackets the metadata after it, e.g.
+	// disk full [ERRO 2024-10-10T10:24:44+06:00 42], path: "/var"
+	MessageFirstLayout = "{msg} [{level} {time} {id}]"
+)
+
+type segmentKind int
+
+const (
+	segmentLiteral segmentKind = iota
+	segmentID
+	segmentLevel
+	segmentTime
+	segmentMessage
+)
+
+// layoutSegment is either literal text or a placeholder of a parsed layout
+type layoutSegment struct {
+	kind segmentKind
+	text string
+}
+
+// WithLayout sets the order and presence of the parts of a text format line using
+// the placeholders {id}, {level}, {time} and {msg}, e.g. "{time} {level} {msg}"
+// to drop the ID. Anything else is written literally; fields always follow.
+func WithLayout(layout string) Option {
+	return func(l *Logger) {
+		l.enc.layout = parseLayout(layout)
+	}
+}
+
+// WithPrefix writes prefix, e.g. "[myapp]", before the message of every text
+// format line, to tell programs apart when their logs are multiplexed
+func WithPrefix(prefix string) Option {
+	return func(l *Logger) {
+		l.enc.prefix = prefix
+	}
+}
+
+// WithFieldSeparator sets what is written between the message and the fields
+// of a text format line, "," by default as in `message, key: "value"`. An empty
+// separator leaves only the space, as in `message key: "value"`.
+func WithFieldSeparator(sep string) Option {
+	return func(l *Logger) {
+		l.enc.fieldSep = sep
+	}
+}
+
+// WithIDWidth zero-pads the IDs of text format lines to width digits, e.g.
+// ID:000123 for 6, so columns stay aligned. Longer IDs are written in full.
+func WithIDWidth(width int) Option {
+	return func(l *Logger) {
+		l.enc.idWidth = width
+	}
+}
+
+// appendID appends logID to dst, zero-padded to the ID width
+func (e *encoding) appendID(dst []byte, logID int32) []byte {
+	for width := idDigits(logID); width < e.idWidth; width++ {
+		dst = append(dst, '0')
+	}
+	return strconv.AppendInt(dst, int64(logID), 10)
+}
+
+// idDigits returns the number of digits of a positive logID
+func idDigits(logID int32) int {
+	n := 1
+	for ; logID >= 10; logID /= 10 {
+		n++
+	}
+	return n
+}
+
+// parseLayout splits a layout into segments, keeping unknown placeholders as literal text
+func parseLayout(layout string) []layoutSegment {
+	var segments []layoutSegment
+	literal := func(text string) {
+		if text == "" {
+			return
+		}
+		if n := len(segments); n > 0 && segments[n-1].kind == segmentLiteral {
+			segments[n-1].text += text
+			return
+		}
+		segments = append(segments, layoutSegment{kind: segmentLiteral, text: text})
+	}
+
+	for layout != "" {
+		open := strings.IndexByte(layout, '{')
+		if open < 0 {
+			literal(layout)
+			break
+		}
+		closing := strings.IndexByte(layout[open:], '}')
+		if closing < 0 {
+			literal(layout)
+			break
+		}
+		closing += open
+
+		literal(layout[:open])
+		switch layout[open+1 : closing] {
+		case "id":
+			segments = append(segments, layoutSegment{kind: segmentID})
+		case "level":
+			segments = append(segments, layoutSegment{kind: segmentLevel})
+		case "time":
+			segments = append(segments, layoutSegment{kind: segmentTime})
+		case "msg":
+			segments = append(segments, layoutSegment{kind: segmentMessage})
+		default:
+			literal(layout[open : closing+1])
+		}
+		layout = layout[closing+1:]
+	}
+	return segments
+}
+
+// appendLayout appends the laid out ID, level, timestamp and message to dst. When
+// colored, the level label is wrapped in its color, which is only reset right
+// after the label if closeColor is set.
+func (e *encoding) appendLayout(dst []byte, level LogLevel, timestamp, message string, logID int32, colored, closeColor bool) []byte {
+	for _, seg := range e.layout {
+		switch seg.kind {
+		case segmentID:
+			dst = e.appendID(dst, logID)
+		case segmentLevel:
+			if colored && !e.errorHighlight {
+				dst = append(dst, e.color(level)...)
+			}
+			dst = append(dst, e.label(level)...)
+			if colored && closeColor && !e.errorHighlight {
+				dst = append(dst, "\033[0m"...)
+			}
+		case segmentTime:
+			dst = append(dst, timestamp...)
+		case segmentMessage:
+			if e.prefix != "" {
+				dst = append(dst, e.prefix...)
+				dst = append(dst, ' ')
+			}
+			if colored && e.highlights(level) {
+				dst = append(dst, errorColor...)
+				dst = append(dst, message...)
+				dst = append(dst, "\033[0m"...)
+				break
+			}
+			dst = append(dst, message...)
+		default:
+			dst = append(dst, seg.text...)
+		}
+	}
+	return dst
+}
+
+// messageOffset returns the number of bytes written before the message in a plain line
+func (e *encoding) messageOffset(level LogLevel, timestamp string, logID int32) int {
+	width := 0
+	for _, seg := range e.layout {
+		switch seg.kind {
+		case segmentID:
+			width += max(len(strconv.Itoa(int(logID))), e.idWidth)
+		case segmentLevel:
+			width += len(e.label(level))
+		case segmentTime:
+			width += len(timestamp)
+		case segmentMessage:
+			if e.prefix != "" {
+				width += len(e.prefix) + 1
+			}
+			return width
+		default:
+			width += len(seg.text)
+		}
+	}
+	return width
+}
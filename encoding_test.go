@@ -0,0 +1,204 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestWithLabelCase(t *testing.T) {
+	tests := []struct {
+		labelCase LabelCase
+		want      []string
+	}{
+		{LabelUpper, []string{"DEBU", "INFO", "WARN", "ERRO", "PANI", "TRAC"}},
+		{LabelLower, []string{"debu", "info", "warn", "erro", "pani", "trac"}},
+		{LabelTitle, []string{"Debu", "Info", "Warn", "Erro", "Pani", "Trac"}},
+	}
+	for _, tt := range tests {
+		logger, out := newTestLogger(WithLabelCase(tt.labelCase), WithLevel(DebugLevel))
+		for level := DebugLevel; level <= TraceLevel; level++ {
+			logger.Log(level, "m")
+		}
+		for i, line := range lines(out) {
+			if !strings.Contains(line, " "+tt.want[i]+" ") {
+				t.Errorf("case %d: line %q, want label %q", tt.labelCase, line, tt.want[i])
+			}
+		}
+	}
+
+	// Every output uses the configured case, and lowercase labels parse back
+	logger, out := newTestLogger(WithLabelCase(LabelLower), WithFormat(FormatJSON))
+	logger.Warn("m")
+	var entry map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &entry); err != nil || entry["level"] != "warn" {
+		t.Errorf("JSON level = %v, %v, want warn", entry["level"], err)
+	}
+	if entry, err := Parse("ID:1 warn 2024-10-10T10:24:44Z m"); err != nil || entry.Level != WarnLevel {
+		t.Errorf("Parse of a lowercase label = %v, %v", entry.Level, err)
+	}
+}
+
+func TestWithLabelPreset(t *testing.T) {
+	logger, out := newTestLogger(WithLabelPreset(LabelsShort), WithLevel(DebugLevel))
+	for level := DebugLevel; level <= TraceLevel; level++ {
+		logger.Log(level, "m")
+	}
+	for i, line := range lines(out) {
+		want := shortLabels[i]
+		if !strings.HasPrefix(line[strings.IndexByte(line, ' ')+1:], want+" ") {
+			t.Errorf("line %q, want label %q", line, want)
+		}
+		if entry, err := Parse(line); err != nil || entry.Level != LogLevel(i) {
+			t.Errorf("Parse(%q) = %v, %v, want level %v", line, entry.Level, err, LogLevel(i))
+		}
+	}
+
+	logger, out = newTestLogger(WithLabelPreset(LabelsShort), WithLabelCase(LabelLower))
+	logger.Error("m")
+	if !strings.Contains(out.String(), " e ") {
+		t.Errorf("line %q, want the short label in lower case", out.String())
+	}
+
+	logger, out = newTestLogger(WithLabelPreset(LabelsShort), WithLabelPreset(LabelsDefault))
+	logger.Error("m")
+	if !strings.Contains(out.String(), " ERRO ") {
+		t.Errorf("line %q, want the default labels restored", out.String())
+	}
+}
+
+func TestWithLayout(t *testing.T) {
+	tests := []struct {
+		layout string
+		want   string
+	}{
+		{DefaultLayout, `INFO 2024-10-10T10:24:44Z served, k: "v"`},
+		{"{time} {level} {msg}", `2024-10-10T10:24:44Z INFO served, k: "v"`},
+		{"[{level}] {msg} @ {time}", `[INFO] served @ 2024-10-10T10:24:44Z, k: "v"`},
+		{"{msg}", `served, k: "v"`},
+		{"{level} {unknown} {msg}", `INFO {unknown} served, k: "v"`},
+	}
+	for _, tt := range tests {
+		logger, out := newTestLogger(WithLayout(tt.layout))
+		logger.AddField("k", "v").Info("served")
+		if got := lines(out)[0]; !strings.HasSuffix(got, tt.want) {
+			t.Errorf("layout %q: line %q, want suffix %q", tt.layout, got, tt.want)
+		}
+	}
+
+	// IDs are the only varying part, check they are placed as laid out
+	logger, out := newTestLogger(WithLayout("{msg} #{id}"))
+	logger.Info("m")
+	if got := lines(out)[0]; !strings.HasPrefix(got, "m #") || strings.Contains(got, "ID:") {
+		t.Errorf("line %q, want the ID after the message", got)
+	}
+}
+
+func TestWithFieldSeparator(t *testing.T) {
+	tests := []struct {
+		opts []Option
+		want string
+	}{
+		{nil, `served, k: "v"`},
+		{[]Option{WithFieldSeparator("")}, `served k: "v"`},
+		{[]Option{WithFieldSeparator(" |")}, `served | k: "v"`},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "app.log")
+		logger, out := newTestLogger(append(tt.opts, WithFile(path))...)
+		logger.AddField("k", "v").Info("served")
+		logger.Info("no fields")
+		_ = logger.Close()
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for name, text := range map[string]string{"terminal": out.String(), "file": string(data)} {
+			got := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+			if !strings.HasSuffix(got[0], tt.want) {
+				t.Errorf("%s: line %q, want suffix %q", name, got[0], tt.want)
+			}
+			if !strings.HasSuffix(got[1], "Z no fields") {
+				t.Errorf("%s: line %q has a separator without fields", name, got[1])
+			}
+		}
+	}
+}
+
+func TestWithLineColor(t *testing.T) {
+	for _, level := range []LogLevel{DebugLevel, InfoLevel, WarnLevel, ErrorLevel, TraceLevel} {
+		var out bytes.Buffer
+		logger := New(WithOutput(&out), WithLevel(DebugLevel), WithLineColor(true), WithTraceCaller(CallerOff))
+		logger.AddField("k", "v").Log(level, "whole line")
+
+		line, color := out.String(), getColor(level)
+		if !strings.HasPrefix(line, color+"ID:") || !strings.HasSuffix(line, "\n\033[0m") {
+			t.Errorf("%v: line %q, want it to start with %q and end with a reset", level, line, color)
+		}
+		if n := strings.Count(line, "\033["); n != 2 {
+			t.Errorf("%v: line %q has %d escapes, want only the color and the reset", level, line, n)
+		}
+	}
+}
+
+func TestWithIDWidth(t *testing.T) {
+	tests := []struct {
+		width int
+		id    int32
+		want  string
+	}{
+		{0, 7, "7"},
+		{6, 7, "000007"},
+		{6, 123, "000123"},
+		{6, 123456, "123456"},
+		{6, 12345678, "12345678"},
+		{3, 1000, "1000"},
+	}
+	for _, tt := range tests {
+		e := newEncoding()
+		e.idWidth = tt.width
+		if got := string(e.appendID(nil, tt.id)); got != tt.want {
+			t.Errorf("width %d: appendID(%d) = %q, want %q", tt.width, tt.id, got, tt.want)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger, out := newTestLogger(WithIDWidth(8), WithFile(path))
+	logger.Info("aligned")
+	_ = logger.Close()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	padded := regexp.MustCompile(`^ID:\d{8} INFO `)
+	for name, line := range map[string]string{"terminal": out.String(), "file": string(data)} {
+		if !padded.MatchString(line) {
+			t.Errorf("%s line %q, want an 8 digit ID", name, line)
+		}
+	}
+}
+
+func TestMessageFirstLayout(t *testing.T) {
+	var colored bytes.Buffer
+	logger, out := newTestLogger(WithLayout(MessageFirstLayout))
+	logger.AddField("k", "v").Error("disk full")
+	New(WithOutput(&colored), WithColor(true), WithLayout(MessageFirstLayout)).Error("disk full")
+
+	line := lines(out)[0]
+	pattern := regexp.MustCompile(`^disk full \[ERRO 2024-10-10T10:24:44Z \d+\], k: "v"$`)
+	if !pattern.MatchString(line) {
+		t.Errorf("line %q, want the message first and the metadata bracketed after it", line)
+	}
+	if !strings.HasPrefix(colored.String(), "disk full [") {
+		t.Errorf("colored line %q does not start with the message", colored.String())
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import "os"
+
+// LevelEnv is the environment variable conventionally used to set the log level
+const LevelEnv = "LOG_LEVEL"
+
+// LevelFromEnv reads a level name such as "debug" from the environment variable
+// varName, returning fallback if the variable is unset, empty or not a known level
+func LevelFromEnv(varName string, fallback LogLevel) LogLevel {
+	levelStr, ok := os.LookupEnv(varName)
+	if !ok {
+		return fallback
+	}
+	if level, ok := lookupLogLevel(levelStr); ok {
+		return level
+	}
+	return fallback
+}
+
+// WithLevelFromEnv sets the level from the environment variable varName (LevelEnv
+// when empty) if it holds a known level. Placed after WithLevel it acts as an
+// override, so each logger can be given its own variable, e.g. "HTTP_LOG_LEVEL".
+func WithLevelFromEnv(varName string) Option {
+	if varName == "" {
+		varName = LevelEnv
+	}
+	return func(l *Logger) {
+		l.setLevel(LevelFromEnv(varName, l.minLevel()))
+	}
+}
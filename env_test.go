@@ -0,0 +1,54 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import "testing"
+
+func TestLevelFromEnv(t *testing.T) {
+	tests := []struct {
+		value string
+		set   bool
+		want  LogLevel
+	}{
+		{"", false, WarnLevel},
+		{"", true, WarnLevel},
+		{"debug", true, DebugLevel},
+		{"ERROR", true, ErrorLevel},
+		{"loud", true, WarnLevel},
+	}
+	for _, tt := range tests {
+		if tt.set {
+			t.Setenv("TROLOG_TEST_LEVEL", tt.value)
+		}
+		if got := LevelFromEnv("TROLOG_TEST_LEVEL", WarnLevel); got != tt.want {
+			t.Errorf("LevelFromEnv with %q (set %v) = %v, want %v", tt.value, tt.set, got, tt.want)
+		}
+	}
+}
+
+func TestWithLevelFromEnv(t *testing.T) {
+	t.Setenv(LevelEnv, "error")
+	t.Setenv("HTTP_LOG_LEVEL", "debug")
+
+	logger, out := newTestLogger(WithLevel(InfoLevel), WithLevelFromEnv(""))
+	logger.Warn("hidden")
+	logger.Error("shown")
+	if got := lines(out); len(got) != 1 {
+		t.Errorf("got lines %q, want only the error with LOG_LEVEL=error", got)
+	}
+
+	logger, out = newTestLogger(WithLevelFromEnv("HTTP_LOG_LEVEL"))
+	logger.Debug("shown")
+	if got := lines(out); len(got) != 1 {
+		t.Errorf("got lines %q, want the debug entry with HTTP_LOG_LEVEL=debug", got)
+	}
+
+	// An unknown level keeps the level set before
+	t.Setenv(LevelEnv, "loud")
+	logger, _ = newTestLogger(WithLevel(WarnLevel), WithLevelFromEnv(""))
+	if got := logger.minLevel(); got != WarnLevel {
+		t.Errorf("level = %v with an unknown LOG_LEVEL, want WARN", got)
+	}
+}
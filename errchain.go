@@ -0,0 +1,42 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+)
+
+// WithErrorChain expands error field values wrapping other errors into a field
+// per layer of the errors.Unwrap chain, up to maxDepth layers, next to the field
+// itself. Layer i, from 0 for the error itself, is written as key.i with its
+// message and key.i.type with its Go type, e.g. for AddField("error", err):
+//
+//	error: "load: open app.yaml: not found" error.0: "load: open app.yaml: not found"
+//	error.0.type: "*fmt.wrapError" error.1: "open app.yaml: not found" error.1.type: "*fs.PathError" ...
+//
+// A maxDepth <= 0 disables the expansion, the default.
+func WithErrorChain(maxDepth int) Option {
+	return func(l *Logger) {
+		l.errChain = maxDepth
+	}
+}
+
+// appendErrorChain sets the layer fields of the error chain of value under key,
+// if the expansion is enabled and value is an error
+func (l *Logger) appendErrorChain(fields []field, key string, value interface{}) []field {
+	err, ok := value.(error)
+	if l.errChain <= 0 || !ok || isNilPointer(err) {
+		return fields
+	}
+	for i := 0; err != nil && i < l.errChain; i++ {
+		layer := key + "." + strconv.Itoa(i)
+		fields = setFieldInPlace(fields, layer, err.Error())
+		fields = setFieldInPlace(fields, layer+".type", reflect.TypeOf(err).String())
+		err = errors.Unwrap(err)
+	}
+	return fields
+}
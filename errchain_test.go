@@ -0,0 +1,66 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestWithErrorChain(t *testing.T) {
+	root := &testError{404}
+	err := fmt.Errorf("load config: %w", root)
+
+	logger, out := newTestLogger(WithErrorChain(4))
+	logger.AddField("error", err).Error("startup failed")
+
+	entry, perr := Parse(lines(out)[0])
+	if perr != nil {
+		t.Fatal(perr)
+	}
+	want := map[string]string{
+		"error":        "load config: error 404",
+		"error.0":      "load config: error 404",
+		"error.0.type": "*fmt.wrapError",
+		"error.1":      "error 404",
+		"error.1.type": "*trolog.testError",
+	}
+	if len(entry.Fields) != len(want) {
+		t.Errorf("fields = %v, want %v", entry.Fields, want)
+	}
+	for k, v := range want {
+		if entry.Fields[k] != v {
+			t.Errorf("%s = %q, want %q", k, entry.Fields[k], v)
+		}
+	}
+}
+
+func TestWithErrorChainDepth(t *testing.T) {
+	err := errors.New("root")
+	for i := 0; i < 5; i++ {
+		err = fmt.Errorf("layer %d: %w", i, err)
+	}
+
+	logger, out := newTestLogger(WithErrorChain(2))
+	logger.AddField("error", err).Error("deep")
+	line := lines(out)[0]
+	if !strings.Contains(line, "error.1:") || strings.Contains(line, "error.2") {
+		t.Errorf("line %q, want only 2 layers", line)
+	}
+
+	// Disabled by default, and non-errors are left alone
+	logger, out = newTestLogger()
+	logger.AddField("error", err).AddField("k", "v").Error("flat")
+	if strings.Contains(lines(out)[0], "error.0") {
+		t.Errorf("line %q expands the chain by default", lines(out)[0])
+	}
+	logger, out = newTestLogger(WithErrorChain(2))
+	logger.AddField("error", "just text").Error("string")
+	if strings.Contains(lines(out)[0], "error.0") {
+		t.Errorf("line %q expands a string value", lines(out)[0])
+	}
+}
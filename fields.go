@@ -0,0 +1,146 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import "sort"
+
+// FieldOrder selects the order in which fields are written
+type FieldOrder int
+
+const (
+	OrderInserted FieldOrder = iota // logger fields as added, then the entry's own fields
+	OrderSorted                     // all fields sorted by key
+)
+
+// WithFieldOrder sets the order of fields on the terminal and in the log files
+// and sinks separately, e.g. OrderInserted for the terminal and OrderSorted for
+// files that are grepped and diffed. Both default to OrderInserted. Colored log
+// files, see WithColorFile, follow the terminal order.
+func WithFieldOrder(terminal, file FieldOrder) Option {
+	return func(l *Logger) {
+		l.termOrder = terminal
+		l.fileOrder = file
+	}
+}
+
+// field is a logger field. Loggers keep their fields in a slice, in the order
+// they were added, which is the order they are written in.
+type field struct {
+	key, value string
+}
+
+// Fields returns a copy of the fields the logger adds to every entry, including
+// global fields and those added with AddField, Named or PushField. Changing the map
+// does not affect the logger.
+func (l *Logger) Fields() map[string]string {
+	return mergeFields(l.loadState().fields, nil)
+}
+
+// NumFields returns the number of fields the logger adds to every entry
+func (l *Logger) NumFields() int {
+	return len(l.loadState().fields)
+}
+
+// setField returns a copy of fields with key set to value, keeping the position
+// of an existing key and appending a new one
+func setField(fields []field, key, value string) []field {
+	out := make([]field, len(fields), len(fields)+1)
+	copy(out, fields)
+	for i := range out {
+		if out[i].key == key {
+			out[i].value = value
+			return out
+		}
+	}
+	return append(out, field{key: key, value: value})
+}
+
+// setFieldInPlace sets key to value in fields, which must not be shared,
+// appending it if it is new
+func setFieldInPlace(fields []field, key, value string) []field {
+	for i := range fields {
+		if fields[i].key == key {
+			fields[i].value = value
+			return fields
+		}
+	}
+	return append(fields, field{key: key, value: value})
+}
+
+// removeField returns a copy of fields without key
+func removeField(fields []field, key string) []field {
+	out := make([]field, 0, len(fields))
+	for _, f := range fields {
+		if f.key != key {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// lookupField returns the value of key in fields
+func lookupField(fields []field, key string) (string, bool) {
+	for _, f := range fields {
+		if f.key == key {
+			return f.value, true
+		}
+	}
+	return "", false
+}
+
+// shadowFields merges the layers of fields of an entry, returning fields without
+// the keys set in extraFields so every key is written once by every encoder.
+// The layers take precedence in the order global fields (see SetGlobalFields),
+// logger fields, which replace globals as they are added, and the fields passed
+// with a single call. fields is returned as is when no key is shadowed.
+func shadowFields(fields []field, extraFields map[string]string) []field {
+	if len(extraFields) == 0 {
+		return fields
+	}
+	for i, f := range fields {
+		if _, ok := extraFields[f.key]; !ok {
+			continue
+		}
+		out := make([]field, i, len(fields)-1)
+		copy(out, fields[:i])
+		for _, f := range fields[i+1:] {
+			if _, ok := extraFields[f.key]; !ok {
+				out = append(out, f)
+			}
+		}
+		return out
+	}
+	return fields
+}
+
+// fieldsFromMap returns the entries of m as fields sorted by key, for a stable order
+func fieldsFromMap(m map[string]string) []field {
+	if len(m) == 0 {
+		return nil
+	}
+	fields := make([]field, 0, len(m))
+	for k, v := range m {
+		fields = append(fields, field{key: k, value: v})
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].key < fields[j].key })
+	return fields
+}
+
+// orderFields returns fields and extraFields as they are written in the given
+// order. Sorted fields are merged into one slice, fields must not repeat the
+// keys of extraFields, see shadowFields.
+func orderFields(order FieldOrder, fields []field, extraFields map[string]string) ([]field, map[string]string) {
+	if order != OrderSorted || len(fields)+len(extraFields) < 2 {
+		return fields, extraFields
+	}
+
+	sorted := make([]field, 0, len(fields)+len(extraFields))
+	sorted = append(sorted, fields...)
+	for k, v := range extraFields {
+		sorted = append(sorted, field{key: k, value: v})
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].key < sorted[j].key })
+	return sorted, nil
+}
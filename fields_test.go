@@ -0,0 +1,80 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFieldsInsertionOrder(t *testing.T) {
+	logger, out := newTestLogger()
+	base := logger.AddField("zeta", "1").AddField("alpha", "2").AddField("mid", "3")
+	base.Info("m", String("beta", "4"))
+	base.AddField("zeta", "5").Info("replaced")
+
+	got := lines(out)
+	if want := `m, zeta: "1" alpha: "2" mid: "3" beta: "4"`; !strings.HasSuffix(got[0], want) {
+		t.Errorf("line %q, want suffix %q", got[0], want)
+	}
+	if want := `replaced, zeta: "5" alpha: "2" mid: "3"`; !strings.HasSuffix(got[1], want) {
+		t.Errorf("line %q, want suffix %q", got[1], want)
+	}
+
+	logger, out = newTestLogger(WithFormat(FormatJSON))
+	logger.AddField("zeta", "1").AddField("alpha", "2").Info("m")
+	if want := `"msg":"m","zeta":"1","alpha":"2"}`; !strings.HasSuffix(strings.TrimSuffix(out.String(), "\n"), want) {
+		t.Errorf("line %q, want suffix %q", out.String(), want)
+	}
+}
+
+func TestWithFieldOrderFileOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger, out := newTestLogger(WithFieldOrder(OrderInserted, OrderSorted), WithFile(path))
+	logger.AddField("zeta", "1").AddField("alpha", "2").Info("m", String("mid", "3"))
+	_ = logger.Close()
+
+	if want := `m, zeta: "1" alpha: "2" mid: "3"`; !strings.HasSuffix(lines(out)[0], want) {
+		t.Errorf("terminal line %q, want suffix %q", lines(out)[0], want)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `m, alpha: "2" mid: "3" zeta: "1"` + "\n"; !strings.HasSuffix(string(data), want) {
+		t.Errorf("file line %q, want suffix %q", data, want)
+	}
+}
+
+func TestFieldsAndNumFields(t *testing.T) {
+	logger, _ := newTestLogger()
+	if logger.NumFields() != 0 || len(logger.Fields()) != 0 {
+		t.Errorf("new logger has fields %v", logger.Fields())
+	}
+
+	derived := logger.AddField("a", 1).AddField("b", "two").AddField("a", "one").Named("svc")
+	derived.PushField("step", "migrate")
+	want := map[string]string{"a": "one", "b": "two", "logger": "svc", "step": "migrate"}
+	if got := derived.Fields(); !reflect.DeepEqual(got, want) || derived.NumFields() != len(want) {
+		t.Errorf("Fields() = %v, NumFields() = %d, want %v", got, derived.NumFields(), want)
+	}
+
+	// The map is a copy
+	derived.Fields()["a"] = "changed"
+	if got := derived.Fields()["a"]; got != "one" {
+		t.Errorf("changing the returned map changed the logger: a = %q", got)
+	}
+
+	derived.PopField()
+	if n := derived.NumFields(); n != 3 {
+		t.Errorf("NumFields() after PopField = %d, want 3", n)
+	}
+	if n := logger.NumFields(); n != 0 {
+		t.Errorf("parent NumFields() = %d, want 0", n)
+	}
+}
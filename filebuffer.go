@@ -0,0 +1,115 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"bufio"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	defaultFileBufferSize    = 64 << 10
+	defaultFileFlushInterval = time.Second
+)
+
+// fileBuffer buffers writes to the log file, flushing them when full, on a timer and on Close
+type fileBuffer struct {
+	mu      sync.Mutex
+	w       *bufio.Writer
+	dst     io.Writer // flushed as well if it buffers, e.g. a gzipFile
+	onError func(error)
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// WithFileBuffer buffers up to size bytes (64 KiB when not positive) of log file
+// output in memory to save a system call per entry under high throughput. The
+// buffer is flushed every interval (one second when not positive), by Flush,
+// Close and Shutdown. Entries still buffered when the process crashes or exits
+// without closing the logger are lost, so keep the interval short where that
+// matters. It has no effect without WithFile.
+func WithFileBuffer(size int, interval time.Duration) Option {
+	return func(l *Logger) {
+		if size <= 0 {
+			size = defaultFileBufferSize
+		}
+		if interval <= 0 {
+			interval = defaultFileFlushInterval
+		}
+		l.fileBufSize = size
+		l.fileFlush = interval
+	}
+}
+
+func newFileBuffer(w io.Writer, size int, interval time.Duration, onError func(error)) *fileBuffer {
+	b := &fileBuffer{
+		w:       bufio.NewWriterSize(w, size),
+		dst:     w,
+		onError: onError,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go b.run(interval)
+	return b
+}
+
+// run flushes the buffer on every tick until stopped
+func (b *fileBuffer) run(interval time.Duration) {
+	defer close(b.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := b.flush(); err != nil && b.onError != nil {
+				b.onError(err)
+			}
+		case <-b.stop:
+			return
+		}
+	}
+}
+
+func (b *fileBuffer) write(p []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	_, err := b.w.Write(p)
+	return err
+}
+
+func (b *fileBuffer) flush() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.w.Flush(); err != nil {
+		return err
+	}
+	if f, ok := b.dst.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// Close stops the timer and flushes what is left
+func (b *fileBuffer) Close() error {
+	b.once.Do(func() { close(b.stop) })
+	<-b.done
+	return b.flush()
+}
+
+// Flush writes buffered log file output to the file, see WithFileBuffer
+func (l *Logger) Flush() error {
+	if l.fileBuf == nil {
+		if l.gzFile != nil {
+			return l.gzFile.Flush()
+		}
+		return nil
+	}
+	return l.fileBuf.flush()
+}
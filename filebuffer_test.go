@@ -0,0 +1,113 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// countingWriter counts the Write calls it receives
+type countingWriter struct {
+	writes int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return len(p), nil
+}
+
+func TestFileBufferTimerFlush(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger, _ := newTestLogger(WithFile(path), WithFileBuffer(4096, 20*time.Millisecond))
+	defer logger.Close()
+
+	logger.Info("buffered")
+	if data, _ := os.ReadFile(path); len(data) != 0 {
+		t.Fatalf("file = %q before the flush interval, want it buffered", data)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(data), "buffered") {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("the entry was not flushed by the timer")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestFileBufferFlushAndClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger, _ := newTestLogger(WithFile(path), WithFileBuffer(4096, time.Hour))
+
+	logger.Info("one")
+	if err := logger.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "one") {
+		t.Errorf("file = %q after Flush", data)
+	}
+
+	logger.Info("two")
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "two") {
+		t.Errorf("file = %q after Close", data)
+	}
+}
+
+// BenchmarkFileBuffer reports the writes reaching the underlying writer per entry
+func BenchmarkFileBuffer(b *testing.B) {
+	line := []byte("ID:1 INFO 2024-10-10T10:24:44Z request served, status: \"200\"\n")
+
+	b.Run("unbuffered", func(b *testing.B) {
+		w := &countingWriter{}
+		for i := 0; i < b.N; i++ {
+			_, _ = w.Write(line)
+		}
+		b.ReportMetric(float64(w.writes)/float64(b.N), "writes/op")
+	})
+	b.Run("buffered", func(b *testing.B) {
+		w := &countingWriter{}
+		buf := newFileBuffer(w, defaultFileBufferSize, time.Hour, nil)
+		for i := 0; i < b.N; i++ {
+			_ = buf.write(line)
+		}
+		_ = buf.Close()
+		b.ReportMetric(float64(w.writes)/float64(b.N), "writes/op")
+	})
+}
+
+func BenchmarkFileLogging(b *testing.B) {
+	for _, bench := range []struct {
+		name string
+		opts []Option
+	}{
+		{"unbuffered", nil},
+		{"buffered", []Option{WithFileBuffer(0, 0)}},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			opts := append([]Option{WithOutput(nil), WithFile(filepath.Join(b.TempDir(), "app.log"))}, bench.opts...)
+			logger := New(opts...)
+			defer logger.Close()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				logger.Info("request served")
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import "time"
+
+// Formatter encodes an entry as a complete log line, including the trailing newline
+type Formatter interface {
+	Format(entry Entry) []byte
+}
+
+// FormatterFunc adapts an ordinary function to the Formatter interface
+type FormatterFunc func(entry Entry) []byte
+
+// Format calls f(entry)
+func (f FormatterFunc) Format(entry Entry) []byte { return f(entry) }
+
+// TextFormatter encodes entries in the default text format
+type TextFormatter struct {
+	TimeFormat string // time.Format layout or epoch format, RFC3339 when empty
+	Colored    bool   // add ANSI colors as on the terminal
+}
+
+// Format implements Formatter
+func (f TextFormatter) Format(entry Entry) []byte {
+	timestamp := formatTimestamp(entry.Time, layoutOrDefault(f.TimeFormat))
+	if !f.Colored {
+		return buildLogMessage(&defaultEncoding, entry.Level, timestamp, entry.Message, fieldsFromMap(entry.Fields), nil, false, entry.ID)
+	}
+
+	line := appendTerminalMessage(nil, &defaultEncoding, entry.Level, timestamp, entry.Message, fieldsFromMap(entry.Fields), nil, nil, entry.ID)
+	if defaultEncoding.colorsLine(entry.Level) {
+		line = append(line, "\033[0m"...)
+	}
+	return line
+}
+
+// JSONFormatter encodes entries as one JSON object per line, like FormatJSON
+type JSONFormatter struct {
+	TimeFormat string // time.Format layout or epoch format, RFC3339 when empty
+}
+
+// Format implements Formatter
+func (f JSONFormatter) Format(entry Entry) []byte {
+	enc := defaultEncoding
+	enc.epochTime = isEpochLayout(f.TimeFormat)
+	timestamp := formatTimestamp(entry.Time, layoutOrDefault(f.TimeFormat))
+	return appendJSONMessage(nil, &enc, entry.Level, timestamp, entry.Message, fieldsFromMap(entry.Fields), nil, entry.ID)
+}
+
+// GELFFormatter encodes entries in Graylog Extended Log Format, like FormatGELF
+type GELFFormatter struct{}
+
+// Format implements Formatter
+func (GELFFormatter) Format(entry Entry) []byte {
+	return appendGELFMessage(nil, &defaultEncoding, entry.Level, entry.Time, entry.Message, fieldsFromMap(entry.Fields), nil, entry.ID)
+}
+
+// CSVFormatter encodes entries as CSV rows, like FormatCSV
+type CSVFormatter struct {
+	TimeFormat string // time.Format layout or epoch format, RFC3339 when empty
+}
+
+// Format implements Formatter
+func (f CSVFormatter) Format(entry Entry) []byte {
+	timestamp := formatTimestamp(entry.Time, layoutOrDefault(f.TimeFormat))
+	return appendCSVMessage(nil, &defaultEncoding, entry.Level, timestamp, entry.Message, fieldsFromMap(entry.Fields), nil, entry.ID)
+}
+
+func layoutOrDefault(layout string) string {
+	if layout == "" {
+		return time.RFC3339
+	}
+	return layout
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestTextFormatterColoredReset(t *testing.T) {
+	for level := DebugLevel; level <= TraceLevel; level++ {
+		entry := Entry{ID: 1, Level: level, Time: testTime, Message: "hello"}
+		line := string(TextFormatter{Colored: true}.Format(entry))
+
+		// Lines colored up to the end are reset after them, others are not
+		want := "hello\n"
+		if defaultEncoding.colorsLine(level) {
+			want += "\033[0m"
+		}
+		if !strings.HasSuffix(line, want) {
+			t.Errorf("%v: line %q does not end with %q", level, line, want)
+		}
+	}
+}
+
+func TestTextFormatterMatchesLogger(t *testing.T) {
+	for level := DebugLevel; level <= TraceLevel; level++ {
+		var out bytes.Buffer
+		logger := New(WithOutput(&out), WithColor(true), WithClock(&fixedClock{testTime}), WithUTC(true), WithLevel(DebugLevel))
+		logger.Log(level, "hello")
+
+		entry, err := Parse(out.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(TextFormatter{Colored: true}.Format(entry)); got != out.String() {
+			t.Errorf("%v: formatter wrote %q, logger wrote %q", level, got, out.String())
+		}
+	}
+}
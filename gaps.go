@@ -0,0 +1,82 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"bufio"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// IDGap is a range of log IDs, From to To inclusive, missing from a log
+type IDGap struct {
+	From, To int32
+}
+
+// maxScanLine is the longest line ScanGaps and Scanner accept
+const maxScanLine = 1 << 20
+
+// ScanGaps reads log lines in the text or JSON format from r and reports the
+// ranges of IDs missing between the lowest and highest ID seen, which point at
+// dropped lines. Lines without an ID, such as output of other programs or
+// continuation lines of multiline messages, are skipped. Since IDs are taken
+// before lines are written, concurrent loggers may write them slightly out of
+// order; this is not reported as a gap.
+//
+// IDs come from a single counter shared by every logger in the process, and
+// every output but the log file skips some of them: the terminal and sinks
+// below the logger's level, level files, routes and added outputs. ScanGaps is
+// therefore only meaningful for the log file (see WithFile) of a process with
+// a single logger and its derived loggers; for any other output or a process
+// with several loggers it reports gaps that are not lost lines.
+func ScanGaps(r io.Reader) ([]IDGap, error) {
+	var ids []int32
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, maxScanLine)
+	for scanner.Scan() {
+		if id, ok := lineID(scanner.Text()); ok {
+			ids = append(ids, id)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var gaps []IDGap
+	for i := 1; i < len(ids); i++ {
+		if ids[i] > ids[i-1]+1 {
+			gaps = append(gaps, IDGap{From: ids[i-1] + 1, To: ids[i] - 1})
+		}
+	}
+	return gaps, nil
+}
+
+// lineID extracts the log ID from a text ("ID:12 ...") or JSON ({"id":12,...}) line
+func lineID(line string) (int32, bool) {
+	var rest string
+	switch {
+	case strings.HasPrefix(line, "ID:"):
+		rest = line[len("ID:"):]
+	case strings.HasPrefix(line, `{"id":`):
+		rest = line[len(`{"id":`):]
+	default:
+		return 0, false
+	}
+
+	end := 0
+	for end < len(rest) && rest[end] >= '0' && rest[end] <= '9' {
+		end++
+	}
+	id, err := strconv.ParseInt(rest[:end], 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return int32(id), true
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestScanGaps(t *testing.T) {
+	log := strings.Join([]string{
+		"ID:10 INFO 2024-10-10T10:24:44Z first",
+		"ID:12 INFO 2024-10-10T10:24:44Z out of order",
+		"ID:11 INFO 2024-10-10T10:24:44Z multi",
+		"  continuation",
+		"output of another program",
+		`{"id":13,"level":"INFO","msg":"json"}`,
+		"ID:17 WARN 2024-10-10T10:24:44Z after a gap",
+		"ID:20 ERRO 2024-10-10T10:24:44Z after another",
+	}, "\n")
+
+	gaps, err := ScanGaps(strings.NewReader(log))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []IDGap{{From: 14, To: 16}, {From: 18, To: 19}}; !reflect.DeepEqual(gaps, want) {
+		t.Errorf("ScanGaps = %v, want %v", gaps, want)
+	}
+}
+
+func TestScanGapsLogger(t *testing.T) {
+	logger, out := newTestLogger(WithSampling(map[LogLevel]int{InfoLevel: 2}))
+	for i := 0; i < 4; i++ {
+		logger.Info("sampled")
+	}
+	logger.Warn("kept")
+
+	// Sampled out entries take no ID, so sampling leaves no gaps
+	gaps, err := ScanGaps(strings.NewReader(out.String()))
+	if err != nil || len(gaps) != 0 {
+		t.Errorf("ScanGaps = %v, %v, want no gaps", gaps, err)
+	}
+}
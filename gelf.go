@@ -0,0 +1,102 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	hostnameOnce sync.Once
+	hostname     string
+)
+
+// getHostname returns the host name reported in GELF messages, looked up once
+func getHostname() string {
+	hostnameOnce.Do(func() {
+		hostname, _ = os.Hostname()
+		if hostname == "" {
+			hostname = "localhost"
+		}
+	})
+	return hostname
+}
+
+// syslogLevel maps a LogLevel to its numeric syslog severity (0 emergency .. 7 debug)
+func syslogLevel(level LogLevel) int {
+	switch level {
+	case PanicLevel:
+		return 2 // Critical
+	case ErrorLevel:
+		return 3 // Error
+	case WarnLevel:
+		return 4 // Warning
+	case InfoLevel:
+		return 6 // Informational
+	default:
+		return 7 // Debug
+	}
+}
+
+// appendGELFMessage appends a log message encoded as a GELF 1.1 JSON object line to dst
+func appendGELFMessage(dst []byte, enc *encoding, level LogLevel, t time.Time, message string, fields []field, extraFields map[string]string, logID int32) []byte {
+	dst = append(dst, `{"version":"1.1","host":`...)
+	dst = appendJSONString(dst, getHostname())
+	dst = append(dst, `,"short_message":`...)
+	dst = appendJSONString(dst, message)
+	dst = append(dst, `,"timestamp":`...)
+	dst = appendEpochSeconds(dst, t)
+	dst = append(dst, `,"level":`...)
+	severity := syslogLevel(level)
+	if enc.severity != nil {
+		severity = enc.severity(level)
+	}
+	dst = strconv.AppendInt(dst, int64(severity), 10)
+	dst = append(dst, `,"_log_id":`...) // "_id" is reserved by GELF
+	dst = strconv.AppendInt(dst, int64(logID), 10)
+
+	for _, f := range fields {
+		key, value := f.key, f.value
+		dst = appendGELFField(dst, key, value)
+	}
+
+	for key, value := range extraFields {
+		dst = appendGELFField(dst, key, value)
+	}
+
+	dst = append(dst, '}', '\n')
+	return dst
+}
+
+// appendGELFField appends a `,"_key":"value"` additional field to dst. The
+// keys "id", reserved by GELF, and "log_id", used for the log ID, are prefixed
+// with "fields." like colliding JSON keys.
+func appendGELFField(dst []byte, key, value string) []byte {
+	if key == "id" || key == "log_id" {
+		key = fieldKeyPrefix + key
+	}
+	dst = append(dst, ',', '"', '_')
+	dst = appendJSONEscaped(dst, key)
+	dst = append(dst, '"', ':')
+	return appendJSONString(dst, value)
+}
+
+// appendEpochSeconds appends t as Unix seconds with millisecond decimals, e.g. 1385053862.307
+func appendEpochSeconds(dst []byte, t time.Time) []byte {
+	millis := t.UnixMilli()
+	dst = strconv.AppendInt(dst, millis/1000, 10)
+	dst = append(dst, '.')
+	frac := millis % 1000
+	if frac < 100 {
+		dst = append(dst, '0')
+	}
+	if frac < 10 {
+		dst = append(dst, '0')
+	}
+	return strconv.AppendInt(dst, frac, 10)
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestGELF(t *testing.T) {
+	clock := &fixedClock{testTime.Add(307 * time.Millisecond)}
+	logger, out := newTestLogger(WithFormat(FormatGELF), WithClock(clock))
+	logger.AddField("user", "ana").AddField("id", "42").Warn("disk almost full")
+
+	var msg map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &msg); err != nil {
+		t.Fatalf("line %q is not JSON: %v", out.String(), err)
+	}
+
+	want := map[string]interface{}{
+		"version":       "1.1",
+		"host":          getHostname(),
+		"short_message": "disk almost full",
+		"timestamp":     1728555884.307,
+		"level":         float64(4),
+		"_user":         "ana",
+		"_fields.id":    "42",
+	}
+	for key, value := range want {
+		if msg[key] != value {
+			t.Errorf("%s = %v, want %v", key, msg[key], value)
+		}
+	}
+	if _, ok := msg["_log_id"].(float64); !ok {
+		t.Errorf("_log_id = %v, want a number", msg["_log_id"])
+	}
+	for key := range msg {
+		switch key {
+		case "version", "host", "short_message", "timestamp", "level":
+		default:
+			if key[0] != '_' {
+				t.Errorf("custom key %q is not prefixed with _", key)
+			}
+		}
+	}
+}
+
+func TestGELFLevels(t *testing.T) {
+	want := map[LogLevel]int{DebugLevel: 7, InfoLevel: 6, WarnLevel: 4, ErrorLevel: 3, PanicLevel: 2, TraceLevel: 7}
+	for level, severity := range want {
+		if got := syslogLevel(level); got != severity {
+			t.Errorf("syslogLevel(%v) = %d, want %d", level, got, severity)
+		}
+	}
+}
+
+func TestGELFFormatter(t *testing.T) {
+	line := GELFFormatter{}.Format(Entry{ID: 1, Level: ErrorLevel, Time: testTime, Message: "m", Fields: map[string]string{"k": "v"}})
+	var msg map[string]interface{}
+	if err := json.Unmarshal(line, &msg); err != nil {
+		t.Fatalf("line %q is not JSON: %v", line, err)
+	}
+	if msg["timestamp"] != 1728555884.0 || msg["_k"] != "v" || msg["level"] != float64(3) {
+		t.Errorf("message = %v", msg)
+	}
+}
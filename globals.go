@@ -0,0 +1,28 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import "sync/atomic"
+
+// globalFields seeds the fields of every logger created by New
+var globalFields atomic.Pointer[[]field]
+
+// SetGlobalFields sets fields, such as version and commit, that every logger
+// created afterwards by New starts with, sorted by key. Call it once at program
+// start, before creating loggers. Globals are the base: fields added to a logger
+// with the same key override them. Loggers that already exist are unaffected,
+// and a nil or empty map clears the globals.
+func SetGlobalFields(fields map[string]string) {
+	f := fieldsFromMap(fields)
+	globalFields.Store(&f)
+}
+
+// loadGlobalFields returns the fields set with SetGlobalFields
+func loadGlobalFields() []field {
+	if f := globalFields.Load(); f != nil {
+		return *f
+	}
+	return nil
+}
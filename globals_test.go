@@ -0,0 +1,78 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSetGlobalFields(t *testing.T) {
+	before, beforeOut := newTestLogger()
+	SetGlobalFields(map[string]string{"version": "1.2.0", "commit": "abc123"})
+	t.Cleanup(func() { SetGlobalFields(nil) })
+
+	logger, out := newTestLogger()
+	logger.Info("started")
+	logger.AddField("version", "override").AddField("k", "v").Info("derived")
+	before.Info("created before")
+
+	got := lines(out)
+	if want := `started, commit: "abc123" version: "1.2.0"`; !strings.HasSuffix(got[0], want) {
+		t.Errorf("line %q, want suffix %q", got[0], want)
+	}
+	if want := `derived, commit: "abc123" version: "override" k: "v"`; !strings.HasSuffix(got[1], want) {
+		t.Errorf("line %q, want suffix %q", got[1], want)
+	}
+	if got := lines(beforeOut)[0]; !strings.HasSuffix(got, "created before") {
+		t.Errorf("logger created before SetGlobalFields has fields: %q", got)
+	}
+
+	SetGlobalFields(nil)
+	logger, out = newTestLogger()
+	logger.Info("cleared")
+	if got := lines(out)[0]; !strings.HasSuffix(got, "cleared") {
+		t.Errorf("line %q still has global fields", got)
+	}
+}
+
+func TestDuplicateKeyPrecedence(t *testing.T) {
+	SetGlobalFields(map[string]string{"user": "global", "region": "eu"})
+	t.Cleanup(func() { SetGlobalFields(nil) })
+	extractor := WithContextExtractor(func(ctx context.Context) map[string]string {
+		return map[string]string{"user": "extra"}
+	})
+
+	for _, format := range []Format{FormatText, FormatJSON} {
+		logger, out := newTestLogger(WithFormat(format), extractor)
+		logger.Info("global")
+		logger = logger.AddField("user", "first").AddField("user", "logger")
+		logger.Info("logger")
+		logger.InfoCtx(context.Background(), "extra")
+		logger.Info("typed", String("user", "typed"))
+
+		got := lines(out)
+		for i, want := range []string{"global", "logger", "extra", "typed"} {
+			var fields map[string]string
+			if format == FormatJSON {
+				var entry struct{ User, Region string }
+				if err := json.Unmarshal([]byte(got[i]), &entry); err != nil {
+					t.Fatal(err)
+				}
+				fields = map[string]string{"user": entry.User, "region": entry.Region}
+			} else if entry, err := Parse(got[i]); err == nil {
+				fields = entry.Fields
+			}
+			if fields["user"] != want || fields["region"] != "eu" {
+				t.Errorf("format %v: line %q, want user %q and the global region", format, got[i], want)
+			}
+			if n := strings.Count(got[i], "user"); n != 1 {
+				t.Errorf("format %v: line %q has the key %d times", format, got[i], n)
+			}
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import "strings"
+
+// Group returns a child logger whose fields added afterwards, with AddField or
+// PushField, belong to the group name. JSON output nests them in an object,
+// {"http":{"method":"GET"}}, while the other formats use dotted keys such as
+// http.method. Groups nest when chained, e.g. Group("http").Group("req").
+func (l *Logger) Group(name string) *Logger {
+	newLogger := l.clone()
+	newLogger.loadState().fields = l.loadState().fields // Shared, fields are replaced rather than modified
+
+	fullName := name
+	if l.group != "" {
+		fullName = l.group + "." + name
+	}
+	newLogger.group = fullName
+
+	groups := make([]string, len(l.enc.groups), len(l.enc.groups)+1)
+	copy(groups, l.enc.groups)
+	newLogger.enc.groups = append(groups, fullName)
+	return newLogger
+}
+
+// groupKey returns key qualified with the logger's group
+func (l *Logger) groupKey(key string) string {
+	if l.group == "" {
+		return key
+	}
+	return l.group + "." + key
+}
+
+// groupOf returns the longest group key belongs to, or "" if none
+func (e *encoding) groupOf(key string) string {
+	best := ""
+	for _, g := range e.groups {
+		if len(g) > len(best) && len(key) > len(g) && key[len(g)] == '.' && strings.HasPrefix(key, g) {
+			best = g
+		}
+	}
+	return best
+}
+
+// jsonGroup collects the fields of a JSON object, including nested groups
+type jsonGroup struct {
+	keys, values []string
+	names        []string
+	children     []*jsonGroup
+}
+
+func (g *jsonGroup) child(name string) *jsonGroup {
+	for i, n := range g.names {
+		if n == name {
+			return g.children[i]
+		}
+	}
+	child := &jsonGroup{}
+	g.names = append(g.names, name)
+	g.children = append(g.children, child)
+	return child
+}
+
+// add places the field in the object of its group
+func (g *jsonGroup) add(enc *encoding, key, value string) {
+	group := enc.groupOf(key)
+	if group == "" {
+		g.keys = append(g.keys, key)
+		g.values = append(g.values, value)
+		return
+	}
+
+	node := g
+	for _, name := range strings.Split(group, ".") {
+		node = node.child(name)
+	}
+	node.keys = append(node.keys, key[len(group)+1:])
+	node.values = append(node.values, value)
+}
+
+// appendJSONGroupedFields appends fields as `,"key":"value"` pairs to dst, with
+// the fields of groups nested in objects
+func appendJSONGroupedFields(dst []byte, enc *encoding, fields []field, extraFields map[string]string) []byte {
+	root := &jsonGroup{}
+	for _, f := range fields {
+		root.add(enc, enc.jsonKey(f.key), f.value)
+	}
+	for key, value := range extraFields {
+		root.add(enc, enc.jsonKey(key), value)
+	}
+	return root.appendMembers(dst)
+}
+
+// appendMembers appends the fields and nested objects of g, each preceded by a comma
+func (g *jsonGroup) appendMembers(dst []byte) []byte {
+	for i, key := range g.keys {
+		dst = appendJSONField(dst, key, g.values[i])
+	}
+	for i, name := range g.names {
+		dst = append(dst, ',')
+		dst = appendJSONString(dst, name)
+		dst = append(dst, ':', '{')
+		start := len(dst)
+		dst = g.children[i].appendMembers(dst)
+		if len(dst) > start {
+			dst = append(dst[:start], dst[start+1:]...) // Drop the leading comma
+		}
+		dst = append(dst, '}')
+	}
+	return dst
+}
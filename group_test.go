@@ -0,0 +1,50 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGroupJSON(t *testing.T) {
+	logger, out := newTestLogger(WithFormat(FormatJSON))
+	logger = logger.AddField("service", "api")
+	http := logger.Group("http").AddField("method", "GET")
+	http.Group("req").AddField("id", "r1").AddField("path", "/a").Info("served")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+		t.Fatalf("line %q is not JSON: %v", out.String(), err)
+	}
+	want := map[string]interface{}{
+		"method": "GET",
+		"req":    map[string]interface{}{"id": "r1", "path": "/a"},
+	}
+	if entry["service"] != "api" || !reflect.DeepEqual(entry["http"], want) {
+		t.Errorf("entry = %v, want service at the top and http nested as %v", entry, want)
+	}
+}
+
+func TestGroupText(t *testing.T) {
+	logger, out := newTestLogger()
+	http := logger.Group("http").AddField("method", "GET")
+	http.Group("req").AddField("id", "r1").Info("served")
+	http.Info("outer")
+	logger.AddField("plain", "yes").Info("ungrouped")
+
+	got := lines(out)
+	for i, want := range []string{
+		`served, http.method: "GET" http.req.id: "r1"`,
+		`outer, http.method: "GET"`,
+		`ungrouped, plain: "yes"`,
+	} {
+		if !strings.HasSuffix(got[i], want) {
+			t.Errorf("line %q, want suffix %q", got[i], want)
+		}
+	}
+}
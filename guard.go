@@ -0,0 +1,85 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+// Guard logs through a logger only if the condition given to If held
+type Guard struct {
+	l *Logger // nil when the condition was false
+}
+
+// If returns a guard that logs only when cond is true, replacing
+// `if cond { l.Info(...) }` with l.If(cond).Info(...). When cond is false
+// nothing is formatted or written, but the arguments of the call are still
+// evaluated by Go before it is made, so wrap expensive ones in an if instead.
+func (l *Logger) If(cond bool) Guard {
+	if !cond {
+		return Guard{}
+	}
+	return Guard{l: l}
+}
+
+// Log methods for different levels, no-ops when the condition was false
+func (g Guard) Info(message string) {
+	if g.l != nil {
+		g.l.log(InfoLevel, message, nil)
+	}
+}
+func (g Guard) Warn(message string) {
+	if g.l != nil {
+		g.l.log(WarnLevel, message, nil)
+	}
+}
+func (g Guard) Error(message string) {
+	if g.l != nil {
+		g.l.log(ErrorLevel, message, nil)
+	}
+}
+func (g Guard) Panic(message string) {
+	if g.l != nil {
+		g.l.log(PanicLevel, message, nil)
+	}
+}
+func (g Guard) Debug(message string) {
+	if g.l != nil {
+		g.l.log(DebugLevel, message, nil)
+	}
+}
+func (g Guard) Trace(message string) {
+	if g.l != nil {
+		g.l.log(TraceLevel, message, nil)
+	}
+}
+
+// Formatted log methods for different levels, the format is only applied when the condition was true
+func (g Guard) Infof(format string, args ...interface{}) {
+	if g.l != nil {
+		g.l.log(InfoLevel, g.l.sprintf(format, args...), nil)
+	}
+}
+func (g Guard) Warnf(format string, args ...interface{}) {
+	if g.l != nil {
+		g.l.log(WarnLevel, g.l.sprintf(format, args...), nil)
+	}
+}
+func (g Guard) Errorf(format string, args ...interface{}) {
+	if g.l != nil {
+		g.l.log(ErrorLevel, g.l.sprintf(format, args...), nil)
+	}
+}
+func (g Guard) Panicf(format string, args ...interface{}) {
+	if g.l != nil {
+		g.l.log(PanicLevel, g.l.sprintf(format, args...), nil)
+	}
+}
+func (g Guard) Debugf(format string, args ...interface{}) {
+	if g.l != nil {
+		g.l.log(DebugLevel, g.l.sprintf(format, args...), nil)
+	}
+}
+func (g Guard) Tracef(format string, args ...interface{}) {
+	if g.l != nil {
+		g.l.log(TraceLevel, g.l.sprintf(format, args...), nil)
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import "testing"
+
+// countingStringer counts how often it is formatted
+type countingStringer struct {
+	calls *int
+}
+
+func (s countingStringer) String() string {
+	*s.calls++
+	return "formatted"
+}
+
+func TestIf(t *testing.T) {
+	logger, out := newTestLogger(WithLevel(DebugLevel))
+	calls := 0
+	arg := countingStringer{&calls}
+
+	guard := logger.If(false)
+	guard.Info("no")
+	guard.Warn("no")
+	guard.Error("no")
+	guard.Panic("no")
+	guard.Debug("no")
+	guard.Trace("no")
+	guard.Infof("%v", arg)
+	guard.Errorf("%v", arg)
+	if out.Len() != 0 || calls != 0 {
+		t.Errorf("false guard wrote %q and formatted its arguments %d times", out.String(), calls)
+	}
+	if stats := logger.Stats(); stats[InfoLevel] != 0 || stats[ErrorLevel] != 0 {
+		t.Errorf("false guard counted entries: %v", stats)
+	}
+
+	logger.If(true).Infof("%v", arg)
+	logger.If(true).Warn("yes")
+	if got := lines(out); len(got) != 2 || calls != 1 {
+		t.Errorf("true guard wrote %q and formatted %d times, want 2 lines and 1 call", got, calls)
+	}
+}
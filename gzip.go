@@ -0,0 +1,61 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"compress/gzip"
+	"io"
+	"sync"
+)
+
+// gzipFile compresses log file output. Writes may come from logging, the file
+// buffer's timer and Flush at once, so it has a lock of its own.
+type gzipFile struct {
+	mu        sync.Mutex
+	gz        *gzip.Writer
+	autoFlush bool // flush after every write, when there is no file buffer
+}
+
+// WithGzip compresses the log file set with WithFile, e.g. "app.log.gz", as it
+// is written. Each logger appends its own gzip member, which gunzip and zcat
+// read as one stream. Compressed data only reaches the file when the compressor
+// is flushed: after every entry by default, which keeps entries durable at some
+// cost in size, or with WithFileBuffer on its interval, by Flush and by Close.
+// The gzip trailer is written by Close or Shutdown; a file left without it, e.g.
+// after a crash, still decompresses up to the last flush, with an error at the end.
+func WithGzip(compress bool) Option {
+	return func(l *Logger) {
+		l.gzip = compress
+	}
+}
+
+func newGzipFile(w io.Writer, autoFlush bool) *gzipFile {
+	return &gzipFile{gz: gzip.NewWriter(w), autoFlush: autoFlush}
+}
+
+func (g *gzipFile) Write(p []byte) (int, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	n, err := g.gz.Write(p)
+	if err == nil && g.autoFlush {
+		err = g.gz.Flush()
+	}
+	return n, err
+}
+
+// Flush writes the data compressed so far to the file
+func (g *gzipFile) Flush() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.gz.Flush()
+}
+
+// Close flushes the data and writes the gzip trailer, leaving the file open
+func (g *gzipFile) Close() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.gz.Close()
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// gunzip returns the decompressed content of the file at path
+func gunzip(t *testing.T, path string) (string, error) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	text, err := io.ReadAll(zr)
+	return string(text), err
+}
+
+func TestWithGzip(t *testing.T) {
+	for _, buffered := range []bool{false, true} {
+		path := filepath.Join(t.TempDir(), "app.log.gz")
+		opts := []Option{WithFile(path), WithGzip(true), WithLayout("{msg}")}
+		if buffered {
+			opts = append(opts, WithFileBuffer(4096, time.Hour))
+		}
+
+		// Two loggers append a gzip member each, read back as one stream
+		for _, msg := range []string{"first", "second"} {
+			logger, _ := newTestLogger(opts...)
+			derived := logger.AddField("k", "v")
+			derived.Info(msg)
+			if err := errors.Join(derived.Close(), logger.Close()); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		text, err := gunzip(t, path)
+		if err != nil || text != "first, k: \"v\"\nsecond, k: \"v\"\n" {
+			t.Errorf("buffered %v: gunzipped %q, %v", buffered, text, err)
+		}
+	}
+}
+
+func TestWithGzipBeforeClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log.gz")
+	logger, _ := newTestLogger(WithFile(path), WithGzip(true), WithLayout("{msg}"))
+	defer logger.Close()
+	logger.Info("durable")
+
+	// Without the trailer the entry is readable, with an error at the end
+	text, err := gunzip(t, path)
+	if text != "durable\n" || err == nil {
+		t.Errorf("gunzipped %q, %v; want the entry and an unexpected EOF", text, err)
+	}
+}
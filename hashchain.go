@@ -0,0 +1,138 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+)
+
+// chainTailSize is how much of an existing log file is read to continue its chain
+const chainTailSize = 64 << 10
+
+// hashChain holds the hash of the last line written to the log file, guarded by outMu
+type hashChain struct {
+	prev [sha256.Size]byte
+	buf  []byte
+}
+
+// WithHashChain ends every line of the log file with a tab and the hex SHA-256
+// of the line chained with the hash of the line before, for audit logs in which
+// edited, inserted or deleted lines must be detected with VerifyChain. The chain
+// continues that of a log file with content, except for a compressed file, which
+// should be new per session. It does not apply to a JSON array log file.
+func WithHashChain(enabled bool) Option {
+	return func(l *Logger) {
+		l.hashChain = enabled
+	}
+}
+
+// newHashChain starts a chain continuing the last hashed line of the log file, if any
+func (l *Logger) newHashChain() *hashChain {
+	c := &hashChain{}
+	if l.gzip {
+		return c
+	}
+	f, err := os.Open(l.file.Name())
+	if err != nil {
+		return c
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil || info.Size() == 0 {
+		return c
+	}
+	start := max(info.Size()-chainTailSize, 0)
+	tail := make([]byte, info.Size()-start)
+	if _, err := f.ReadAt(tail, start); err != nil {
+		return c
+	}
+	tail = bytes.TrimRight(tail, "\r\n")
+	if i := bytes.LastIndexByte(tail, '\n'); i >= 0 {
+		tail = tail[i+1:]
+	}
+	if _, sum, ok := splitChainLine(tail); ok {
+		c.prev = sum
+	}
+	return c
+}
+
+// writeChained writes line to the log file with its chained hash, must be called with outMu held
+func (l *Logger) writeChained(line []byte) error {
+	c := l.chain
+	content := bytes.TrimSuffix(line, []byte(l.lineEnding))
+	c.buf = append(c.buf[:0], c.prev[:]...)
+	c.buf = append(c.buf, content...)
+	c.prev = sha256.Sum256(c.buf)
+
+	c.buf = append(c.buf[:0], content...)
+	c.buf = append(c.buf, '\t')
+	c.buf = hex.AppendEncode(c.buf, c.prev[:])
+	c.buf = append(c.buf, l.lineEnding...)
+	return l.writeFile(c.buf)
+}
+
+// splitChainLine splits a line written with WithHashChain, without its line
+// ending, into its content and hash
+func splitChainLine(line []byte) ([]byte, [sha256.Size]byte, bool) {
+	var sum [sha256.Size]byte
+	n := len(line) - 2*sha256.Size - 1
+	if n < 0 || line[n] != '\t' {
+		return nil, sum, false
+	}
+	if _, err := hex.Decode(sum[:], line[n+1:]); err != nil {
+		return nil, sum, false
+	}
+	return line[:n], sum, true
+}
+
+// VerifyChain reads a log file written with WithHashChain from r and checks
+// that the hash of every entry matches its content and the entry before it,
+// reporting the line of the first mismatch, e.g. after an entry was edited,
+// inserted or deleted. Lines without a hash are part of the entry that
+// follows, as written for multiline messages. Entries removed from the end of
+// the file cannot be detected.
+func VerifyChain(r io.Reader) error {
+	var prev [sha256.Size]byte
+	var entry []byte
+	br := bufio.NewReader(r)
+	for lineNum := 1; ; lineNum++ {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 0 {
+			trimmed := bytes.TrimSuffix(bytes.TrimSuffix(line, []byte("\n")), []byte("\r"))
+			content, sum, ok := splitChainLine(trimmed)
+			if !ok {
+				entry = append(entry, line...)
+			} else {
+				entry = append(entry, content...)
+				h := sha256.New()
+				h.Write(prev[:])
+				h.Write(entry)
+				if !bytes.Equal(h.Sum(nil), sum[:]) {
+					return errors.New("trolog: hash chain broken at line " + strconv.Itoa(lineNum))
+				}
+				prev = sum
+				entry = entry[:0]
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if len(entry) > 0 {
+		return errors.New("trolog: hash chain ends with an unhashed line")
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeChainedLog logs messages to a new hash chained file, one logger per session, and returns its content
+func writeChainedLog(t *testing.T, sessions ...[]string) []byte {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "audit.log")
+	for _, messages := range sessions {
+		logger, _ := newTestLogger(WithFile(path), WithHashChain(true))
+		for _, msg := range messages {
+			logger.AddField("user", "ana").Info(msg)
+		}
+		if err := logger.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestVerifyChain(t *testing.T) {
+	data := writeChainedLog(t, []string{"login", "read report"}, []string{"delete report", "logout"})
+	if err := VerifyChain(bytes.NewReader(data)); err != nil {
+		t.Fatalf("VerifyChain on an untouched file: %v", err)
+	}
+	if err := VerifyChain(strings.NewReader("")); err != nil {
+		t.Errorf("VerifyChain on an empty file: %v", err)
+	}
+
+	fileLines := strings.SplitAfter(string(data), "\n")
+	fileLines = fileLines[:len(fileLines)-1] // Drop the empty string after the last newline
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"edited", strings.Join(fileLines[:2], "") + strings.Replace(fileLines[2], "delete", "archive", 1) + fileLines[3], "line 3"},
+		{"deleted", fileLines[0] + fileLines[2] + fileLines[3], "line 2"},
+		{"inserted", fileLines[0] + fileLines[1] + fileLines[1] + fileLines[2] + fileLines[3], "line 3"},
+		{"reordered", fileLines[1] + fileLines[0] + fileLines[2] + fileLines[3], "line 1"},
+		{"unhashed", strings.Join(fileLines, "") + "forged entry\n", "unhashed"},
+	}
+	for _, tt := range tests {
+		err := VerifyChain(strings.NewReader(tt.text))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: VerifyChain = %v, want an error at %s", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestHashChainMultiline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	logger, _ := newTestLogger(WithFile(path), WithHashChain(true))
+	logger.Info("first\nsecond")
+	logger.Info("after")
+	_ = logger.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyChain(bytes.NewReader(data)); err != nil {
+		t.Errorf("VerifyChain on a multiline entry: %v\n%s", err, data)
+	}
+	if tampered := bytes.Replace(data, []byte("second"), []byte("sec0nd"), 1); VerifyChain(bytes.NewReader(tampered)) == nil {
+		t.Error("VerifyChain missed an edit to a continuation line")
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWithErrorHighlight(t *testing.T) {
+	var out bytes.Buffer
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger := New(WithOutput(&out), WithColor(true), WithLineColor(true), WithErrorHighlight(true), WithFile(path), WithLayout("{level} {msg}"))
+	logger.AddField("error", errors.New("denied")).AddField("user", "ana").Error("request failed")
+	logger.AddField("error", "retrying").Warn("slow")
+	logger.Info("fine")
+	_ = logger.Close()
+
+	got := strings.Split(out.String(), "\n")
+	want := []string{
+		"ERRO \033[31mrequest failed\033[0m, error: \"\033[31mdenied\033[0m\" user: \"ana\"",
+		"WARN slow, error: \"\033[31mretrying\033[0m\"",
+		"INFO fine",
+		"",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("lines = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("line %q, want %q", got[i], want[i])
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte("\033[")) {
+		t.Errorf("file %q is colored", data)
+	}
+}
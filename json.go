@@ -0,0 +1,125 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"strconv"
+	"unicode/utf8"
+)
+
+const hexDigits = "0123456789abcdef"
+
+// appendJSONMessage appends a log message encoded as a single JSON object line to dst
+func appendJSONMessage(dst []byte, enc *encoding, level LogLevel, timestamp, message string, fields []field, extraFields map[string]string, logID int32) []byte {
+	dst = append(dst, `{"id":`...)
+	dst = strconv.AppendInt(dst, int64(logID), 10)
+	if !enc.severityOnly {
+		dst = append(dst, `,"level":`...)
+		dst = appendJSONString(dst, enc.label(level))
+	}
+	if enc.severity != nil {
+		dst = append(dst, ',')
+		dst = appendJSONString(dst, enc.severityKey)
+		dst = append(dst, ':')
+		dst = strconv.AppendInt(dst, int64(enc.severity(level)), 10)
+	}
+	dst = append(dst, `,"time":`...)
+	if enc.epochTime {
+		dst = append(dst, timestamp...)
+	} else {
+		dst = appendJSONString(dst, timestamp)
+	}
+	dst = append(dst, `,"msg":`...)
+	dst = appendJSONString(dst, message)
+
+	if len(enc.groups) > 0 {
+		dst = appendJSONGroupedFields(dst, enc, fields, extraFields)
+		return append(dst, '}', '\n')
+	}
+
+	for _, f := range fields {
+		dst = appendJSONField(dst, enc.jsonKey(f.key), f.value)
+	}
+
+	for key, value := range extraFields {
+		dst = appendJSONField(dst, enc.jsonKey(key), value)
+	}
+
+	dst = append(dst, '}', '\n')
+	return dst
+}
+
+// fieldKeyPrefix is put before field keys that would duplicate a key of the entry itself
+const fieldKeyPrefix = "fields."
+
+// jsonKey returns the key a field is written with in JSON, prefixed with
+// "fields." if it is one of the keys of the entry itself, e.g. "fields.msg",
+// so decoders do not lose the message or level to a field
+func (e *encoding) jsonKey(key string) string {
+	switch key {
+	case "id", "level", "time", "msg":
+		return fieldKeyPrefix + key
+	}
+	if e.severity != nil && key == e.severityKey {
+		return fieldKeyPrefix + key
+	}
+	return key
+}
+
+// appendJSONField appends a `,"key":"value"` pair to dst
+func appendJSONField(dst []byte, key, value string) []byte {
+	dst = append(dst, ',')
+	dst = appendJSONString(dst, key)
+	dst = append(dst, ':')
+	return appendJSONString(dst, value)
+}
+
+// appendJSONString appends s to dst as a quoted and escaped JSON string
+func appendJSONString(dst []byte, s string) []byte {
+	dst = append(dst, '"')
+	dst = appendJSONEscaped(dst, s)
+	return append(dst, '"')
+}
+
+// appendJSONEscaped appends s to dst escaped for use inside a JSON string
+func appendJSONEscaped(dst []byte, s string) []byte {
+	start := 0
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c >= utf8.RuneSelf {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			if r == utf8.RuneError && size == 1 {
+				dst = append(dst, s[start:i]...)
+				dst = append(dst, `\ufffd`...)
+				i += size
+				start = i
+				continue
+			}
+			i += size
+			continue
+		}
+		if c >= 0x20 && c != '"' && c != '\\' {
+			i++
+			continue
+		}
+
+		dst = append(dst, s[start:i]...)
+		switch c {
+		case '"', '\\':
+			dst = append(dst, '\\', c)
+		case '\n':
+			dst = append(dst, '\\', 'n')
+		case '\r':
+			dst = append(dst, '\\', 'r')
+		case '\t':
+			dst = append(dst, '\\', 't')
+		default:
+			dst = append(dst, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xf])
+		}
+		i++
+		start = i
+	}
+	return append(dst, s[start:]...)
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJSONFieldKeyCollisions(t *testing.T) {
+	logger, out := newTestLogger(WithFormat(FormatJSON))
+	logger.AddField("msg", "x").AddField("level", "y").AddField("id", "z").AddField("time", "w").Info("hello")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+		t.Fatalf("line %q is not JSON: %v", out.String(), err)
+	}
+	want := map[string]interface{}{
+		"msg": "hello", "level": "INFO",
+		"fields.msg": "x", "fields.level": "y", "fields.id": "z", "fields.time": "w",
+	}
+	for key, value := range want {
+		if entry[key] != value {
+			t.Errorf("%s = %v, want %v in %s", key, entry[key], value, out.String())
+		}
+	}
+}
+
+func TestJSONFieldKeyCollisionsGrouped(t *testing.T) {
+	logger, out := newTestLogger(WithFormat(FormatJSON))
+	logger.Group("http").AddField("msg", "in group").AddField("level", "y").Info("hello")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+		t.Fatalf("line %q is not JSON: %v", out.String(), err)
+	}
+	if entry["msg"] != "hello" || entry["level"] != "INFO" {
+		t.Errorf("entry = %s", out.String())
+	}
+}
+
+func TestWithJSONArray(t *testing.T) {
+	for _, buffered := range []bool{false, true} {
+		path := filepath.Join(t.TempDir(), "app.json")
+		opts := []Option{WithFormat(FormatJSON), WithJSONArray(true), WithFile(path)}
+		if buffered {
+			opts = append(opts, WithFileBuffer(4096, time.Hour))
+		}
+		logger, out := newTestLogger(opts...)
+		derived := logger.AddField("k", "v")
+		logger.Info("one")
+		derived.Warn("two")
+		logger.Error("three")
+		if err := errors.Join(derived.Close(), logger.Close()); err != nil {
+			t.Fatal(err)
+		}
+		logger.Info("after close")
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var entries []map[string]interface{}
+		if err := json.Unmarshal(data, &entries); err != nil {
+			t.Fatalf("buffered %v: file %q is not a JSON array: %v", buffered, data, err)
+		}
+		if len(entries) != 3 || entries[0]["msg"] != "one" || entries[1]["k"] != "v" || entries[2]["msg"] != "three" {
+			t.Errorf("buffered %v: entries = %v", buffered, entries)
+		}
+		// The terminal still gets JSON Lines
+		if n := strings.Count(out.String(), "\n"); n != 4 || strings.HasPrefix(out.String(), "[") {
+			t.Errorf("terminal %q, want one object per line", out.String())
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "empty.json")
+	logger, _ := newTestLogger(WithFormat(FormatJSON), WithJSONArray(true), WithFile(path))
+	_ = logger.Close()
+	var entries []map[string]interface{}
+	if data, err := os.ReadFile(path); err != nil || json.Unmarshal(data, &entries) != nil || entries == nil || len(entries) != 0 {
+		t.Errorf("empty session file = %q, %v, want an empty array", data, err)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import "bytes"
+
+// jsonArray tracks the JSON array written to the log file, shared with derived
+// loggers and guarded by outMu
+type jsonArray struct {
+	started bool
+	closed  bool
+}
+
+// WithJSONArray writes the log file as a single JSON array of the entries of the
+// session rather than one JSON object per line, for tools that load the whole
+// file as one document. The array is closed by Close or Shutdown, and a session
+// without entries writes "[]". Each session writes an array of its own, so use a
+// new file per session. It only applies to FormatJSON; the terminal, per-level
+// files and sinks still get one object per line.
+func WithJSONArray(enabled bool) Option {
+	return func(l *Logger) {
+		l.jsonArray = enabled
+	}
+}
+
+// writeArrayElement writes line to the log file as the next array element, must be called with outMu held
+func (l *Logger) writeArrayElement(line []byte) error {
+	a := l.array
+	if a.closed {
+		return nil
+	}
+	sep := ",\n"
+	if !a.started {
+		sep = "[\n"
+		a.started = true
+	}
+	if err := l.writeFile([]byte(sep)); err != nil {
+		return err
+	}
+	return l.writeFile(bytes.TrimSuffix(line, []byte(l.lineEnding)))
+}
+
+// closeArray ends the JSON array in the log file
+func (l *Logger) closeArray() error {
+	l.outMu.Lock()
+	defer l.outMu.Unlock()
+
+	a := l.array
+	if a.closed {
+		return nil
+	}
+	a.closed = true
+	if !a.started {
+		return l.writeFile([]byte("[]\n"))
+	}
+	return l.writeFile([]byte("\n]\n"))
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// KeyPolicy controls what happens to field keys that would break the text format
+// or structured parsers, those that are empty or contain spaces, control
+// characters or any of :",=
+type KeyPolicy int
+
+const (
+	KeysAsIs     KeyPolicy = iota // keys are used unchanged
+	KeysSanitize                  // illegal characters are replaced with '_'
+	KeysStrict                    // fields with illegal keys are dropped and reported to the OnError handler
+)
+
+// WithKeyPolicy sets how keys given to AddField, AddBytes and PushField are checked
+func WithKeyPolicy(policy KeyPolicy) Option {
+	return func(l *Logger) {
+		l.keyPolicy = policy
+	}
+}
+
+// fieldKey returns key qualified with the logger's group and checked against
+// the key policy, or false if the field should be dropped
+func (l *Logger) fieldKey(key string) (string, bool) {
+	switch l.keyPolicy {
+	case KeysSanitize:
+		key = sanitizeKey(key)
+	case KeysStrict:
+		if !validKey(key) {
+			l.reportError(errors.New("trolog: invalid field key " + strconv.Quote(key)))
+			return "", false
+		}
+	}
+	return l.groupKey(key), true
+}
+
+func illegalKeyRune(r rune) bool {
+	return unicode.IsSpace(r) || unicode.IsControl(r) || strings.ContainsRune(`:",=`, r)
+}
+
+func validKey(key string) bool {
+	return key != "" && strings.IndexFunc(key, illegalKeyRune) < 0
+}
+
+// sanitizeKey replaces illegal characters in key with '_', and an empty key with "_"
+func sanitizeKey(key string) string {
+	if validKey(key) {
+		return key
+	}
+	if key == "" {
+		return "_"
+	}
+	return strings.Map(func(r rune) rune {
+		if illegalKeyRune(r) {
+			return '_'
+		}
+		return r
+	}, key)
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestKeyPolicySanitize(t *testing.T) {
+	logger, out := newTestLogger(WithKeyPolicy(KeysSanitize))
+	logger.AddField("user name", "ana").AddField("http:status", "200").AddField("", "empty").
+		Info("m", String("a=b", "1"))
+
+	if want := `m, user_name: "ana" http_status: "200" _: "empty" a_b: "1"`; !strings.HasSuffix(lines(out)[0], want) {
+		t.Errorf("line %q, want suffix %q", lines(out)[0], want)
+	}
+	if got := sanitizeKey("ok_key.1"); got != "ok_key.1" {
+		t.Errorf("sanitizeKey changed a valid key to %q", got)
+	}
+}
+
+func TestKeyPolicyStrict(t *testing.T) {
+	var rejected []string
+	logger, out := newTestLogger(
+		WithKeyPolicy(KeysStrict),
+		WithOnError(func(err error) { rejected = append(rejected, err.Error()) }),
+	)
+	logger.AddField("user name", "ana").AddField("http:status", "200").AddField("ok", "yes").
+		Info("m", String("a b", "1"))
+
+	if got := lines(out)[0]; !strings.HasSuffix(got, `m, ok: "yes"`) {
+		t.Errorf("line %q, want only the valid field", got)
+	}
+	want := []string{
+		`trolog: invalid field key "user name"`,
+		`trolog: invalid field key "http:status"`,
+		`trolog: invalid field key "a b"`,
+	}
+	if strings.Join(rejected, "\n") != strings.Join(want, "\n") {
+		t.Errorf("reported %q, want %q", rejected, want)
+	}
+}
+
+func TestKeyPolicyAsIs(t *testing.T) {
+	logger, out := newTestLogger()
+	logger.AddField("user name", "ana").Info("m")
+	if got := lines(out)[0]; !strings.HasSuffix(got, `m, user name: "ana"`) {
+		t.Errorf("line %q, want the key unchanged", got)
+	}
+}
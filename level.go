@@ -0,0 +1,69 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"errors"
+	"strconv"
+)
+
+// logLevelNames are the level names accepted by NewLogger and written by MarshalText
+var logLevelNames = [...]string{"debug", "info", "warn", "error", "panic", "trace"}
+
+// String returns the label of the level as written in the default text format,
+// e.g. "INFO", or "UNKNOWN" for a level outside DebugLevel to TraceLevel
+func (level LogLevel) String() string {
+	if level < DebugLevel || level > TraceLevel {
+		return logLevelStrings[len(logLevelStrings)-1]
+	}
+	return logLevelStrings[level]
+}
+
+// MarshalText returns the name of the level, e.g. "info", so levels can be used
+// directly in JSON or YAML configuration
+func (level LogLevel) MarshalText() ([]byte, error) {
+	if level < DebugLevel || level > TraceLevel {
+		return nil, errors.New("trolog: unknown level " + strconv.Itoa(int(level)))
+	}
+	return []byte(logLevelNames[level]), nil
+}
+
+// UnmarshalText sets the level from a name such as "info", see ParseLevel
+func (level *LogLevel) UnmarshalText(text []byte) error {
+	l, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*level = l
+	return nil
+}
+
+// ParseLevel converts a level name to a LogLevel. Names are matched in any
+// letter case and may also be a label such as "WARN", an alias ("dbg",
+// "warning", "err", "fatal" or "critical" for PanicLevel, "trc") or the
+// number of the level, "0" for debug to "5" for trace. Unknown names are
+// reported as an error rather than falling back to a level.
+func ParseLevel(name string) (LogLevel, error) {
+	level, ok := lookupLogLevel(name)
+	if !ok {
+		return InfoLevel, errors.New("trolog: unknown level " + strconv.Quote(name))
+	}
+	return level, nil
+}
+
+// atLeast reports whether level is at least as severe as min. TraceLevel has
+// the highest number but is the least severe level, below DebugLevel, so a
+// minimum such as ErrorLevel does not let Trace entries through.
+func (level LogLevel) atLeast(min LogLevel) bool {
+	return severityRank(level) >= severityRank(min)
+}
+
+// severityRank orders levels by severity, Trace first
+func severityRank(level LogLevel) int {
+	if level == TraceLevel {
+		return -1
+	}
+	return int(level)
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestUnknownLevel(t *testing.T) {
+	for _, level := range []LogLevel{LogLevel(99), LogLevel(-1), TraceLevel + 1} {
+		if got := getColor(level); got != "\033[0m" {
+			t.Errorf("getColor(%d) = %q, want the default color", level, got)
+		}
+		if got := level.String(); got != "UNKNOWN" {
+			t.Errorf("LogLevel(%d).String() = %q, want UNKNOWN", level, got)
+		}
+		if _, err := level.MarshalText(); err == nil {
+			t.Errorf("LogLevel(%d).MarshalText() did not fail", level)
+		}
+	}
+
+	var out bytes.Buffer
+	logger := New(WithOutput(&out), WithLevel(TraceLevel), WithColor(true))
+	logger.Log(LogLevel(99), "odd level")
+	if !strings.Contains(out.String(), "UNKNOWN") || !strings.Contains(out.String(), "odd level") {
+		t.Errorf("line %q, want the UNKNOWN label", out.String())
+	}
+	if n := logger.Stats()[LogLevel(99)]; n != 0 {
+		t.Errorf("Stats counted %d entries for an unknown level", n)
+	}
+}
+
+func TestLevelTextRoundTrip(t *testing.T) {
+	for level := DebugLevel; level <= TraceLevel; level++ {
+		text, err := level.MarshalText()
+		if err != nil {
+			t.Fatalf("%v: MarshalText: %v", level, err)
+		}
+		var got LogLevel
+		if err := got.UnmarshalText(text); err != nil || got != level {
+			t.Errorf("%v: round trip through %q = %v, %v", level, text, got, err)
+		}
+		if err := got.UnmarshalText([]byte(level.String())); err != nil || got != level {
+			t.Errorf("%v: UnmarshalText(%q) = %v, %v", level, level.String(), got, err)
+		}
+	}
+
+	var config struct {
+		Level LogLevel `json:"level"`
+	}
+	if err := json.Unmarshal([]byte(`{"level":"WARNING"}`), &config); err != nil || config.Level != WarnLevel {
+		t.Errorf("unmarshaled level = %v, %v", config.Level, err)
+	}
+	if data, err := json.Marshal(config); err != nil || string(data) != `{"level":"warn"}` {
+		t.Errorf("marshaled config = %s, %v", data, err)
+	}
+	if err := json.Unmarshal([]byte(`{"level":"loud"}`), &config); err == nil {
+		t.Error("unmarshaling an unknown level did not fail")
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := map[LogLevel][]string{
+		DebugLevel: {"debug", "DEBUG", "Dbg", "debu", "0"},
+		InfoLevel:  {"info", "INFO", "Information", " info ", "1"},
+		WarnLevel:  {"warn", "Warning", "WARN", "2"},
+		ErrorLevel: {"error", "err", "ERRO", "3"},
+		PanicLevel: {"panic", "fatal", "Critical", "crit", "PANI", "4"},
+		TraceLevel: {"trace", "TRC", "trac", "5"},
+	}
+	for want, names := range tests {
+		for _, name := range names {
+			if got, err := ParseLevel(name); err != nil || got != want {
+				t.Errorf("ParseLevel(%q) = %v, %v, want %v", name, got, err, want)
+			}
+		}
+	}
+
+	for _, name := range []string{"", "loud", "6", "-1", "warnings"} {
+		if got, err := ParseLevel(name); err == nil {
+			t.Errorf("ParseLevel(%q) = %v, want an error", name, got)
+		}
+	}
+	// NewLogger keeps falling back to info for unknown names
+	if got := logLevelFromString("loud"); got != InfoLevel {
+		t.Errorf("logLevelFromString(\"loud\") = %v, want INFO", got)
+	}
+}
+
+func TestLevelAtLeast(t *testing.T) {
+	order := []LogLevel{TraceLevel, DebugLevel, InfoLevel, WarnLevel, ErrorLevel, PanicLevel}
+	for i, level := range order {
+		for j, min := range order {
+			if got := level.atLeast(min); got != (i >= j) {
+				t.Errorf("%v.atLeast(%v) = %v, want %v", level, min, got, i >= j)
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"os"
+	"sync"
+)
+
+// levelFile is a log file receiving entries from minLevel to maxLevel, opened on first use
+type levelFile struct {
+	minLevel, maxLevel LogLevel
+	path               string
+
+	mu     sync.Mutex
+	file   *os.File
+	failed bool // opening failed, entries are dropped
+	closed bool
+}
+
+// WithLevelFile additionally writes entries from minLevel to maxLevel (inclusive)
+// to the file at path, e.g. WithLevelFile(ErrorLevel, PanicLevel, "errors.log").
+// Like the main log file it ignores the logger's level. The file is opened on
+// the first matching entry and closed by Close.
+func WithLevelFile(minLevel, maxLevel LogLevel, path string) Option {
+	return func(l *Logger) {
+		l.levelFiles = append(l.levelFiles, &levelFile{minLevel: minLevel, maxLevel: maxLevel, path: path})
+	}
+}
+
+func (f *levelFile) matches(level LogLevel) bool {
+	return level >= f.minLevel && level <= f.maxLevel
+}
+
+// write appends line to the file, opening it first if needed. A failure to open
+// is reported once, after which entries for the file are dropped.
+func (f *levelFile) write(line []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		if f.failed || f.closed {
+			return nil
+		}
+		file, err := openLogFile(f.path)
+		if err != nil {
+			f.failed = true
+			return err
+		}
+		f.file = file
+	}
+	_, err := f.file.Write(line)
+	return err
+}
+
+// sync commits the file to disk if it has been opened
+func (f *levelFile) sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	return f.file.Sync()
+}
+
+func (f *levelFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.closed = true
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWithLevelFile(t *testing.T) {
+	dir := t.TempDir()
+	debugPath := filepath.Join(dir, "debug.log")
+	errorsPath := filepath.Join(dir, "errors.log")
+	unusedPath := filepath.Join(dir, "trace.log")
+	logger, _ := newTestLogger(
+		WithLevel(ErrorLevel),
+		WithLevelFile(DebugLevel, InfoLevel, debugPath),
+		WithLevelFile(ErrorLevel, PanicLevel, errorsPath),
+		WithLevelFile(TraceLevel, TraceLevel, unusedPath),
+	)
+
+	logger.Debug("debug")
+	logger.Info("info")
+	logger.Warn("warn")
+	logger.Error("error")
+	logger.Panic("panic")
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for path, want := range map[string][]string{
+		debugPath:  {"debug", "info"},
+		errorsPath: {"error", "panic"},
+	} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+		if len(got) != len(want) {
+			t.Fatalf("%s has lines %q, want %q", filepath.Base(path), got, want)
+		}
+		for i := range want {
+			if !strings.HasSuffix(got[i], " "+want[i]) {
+				t.Errorf("%s line %q, want message %q", filepath.Base(path), got[i], want[i])
+			}
+		}
+	}
+
+	if _, err := os.Stat(unusedPath); !os.IsNotExist(err) {
+		t.Errorf("file without matching entries was created: %v", err)
+	}
+}
@@ -5,9 +5,12 @@
 package trolog
 
 import (
+	"errors"
 	"io"
 	"os"
+	"reflect"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -32,12 +35,66 @@ var logIDCounter int32 // Using atomic for thread-safe incrementing
 
 // Logger is a structured logger with configurable options
 type Logger struct {
-	level   LogLevel
-	output  io.Writer
-	file    *os.File
-	colored bool
-	mu      sync.Mutex
-	fields  map[string]string
+	level           *atomic.Int32 // shared with derived loggers, see WithLevelReload
+	output          io.Writer
+	file            *os.File
+	colored         bool
+	colorFile       bool
+	format          Format
+	timeFormat      string
+	utc             bool
+	location        *time.Location // see WithLocation, overrides utc
+	start           time.Time      // reference for elapsed timestamps
+	elapsed         time.Duration  // precision of elapsed timestamps, 0 when disabled
+	csvHeader       bool
+	formatter       Formatter
+	multiline       MultilineMode
+	lineEnding      string
+	name            string
+	group           string // qualifies keys of added fields, see Group
+	byteUnits       ByteUnits
+	percentDigits   int
+	dropCanceled    bool
+	keyPolicy       KeyPolicy
+	banner          map[string]string // written once by New, see WithBanner
+	redactors       []redactor
+	repanic         bool
+	panicAction     Action
+	fatalAction     Action
+	fmtFallback     bool
+	caller          CallerMode
+	traceCaller     CallerMode // used for Trace entries when caller is CallerOff
+	shortCallerFunc bool
+	termOrder       FieldOrder
+	fileOrder       FieldOrder
+	fieldStack      []pushedField
+	refs            *atomic.Int32 // open loggers sharing the outputs, see Close
+	closed          atomic.Bool
+	extractors      []ContextExtractor
+	enc             encoding
+	levelFiles      []*levelFile
+	onError         func(error)
+	sampler         *sampler
+	reloader        *levelReloader
+	dropReporter    *dropReporter
+	fileBuf         *fileBuffer // buffers file writes, nil when unbuffered
+	gzip            bool
+	gzFile          *gzipFile // compresses file writes, nil when uncompressed
+	errChain        int       // error chain layers expanded, see WithErrorChain
+	hashChain       bool
+	clock           Clock
+	chain           *hashChain // hashes of the log file lines, see WithHashChain
+	jsonArray       bool
+	array           *jsonArray // the log file's JSON array, see WithJSONArray
+	fileBufSize     int
+	fileFlush       time.Duration
+	syncLevel       LogLevel // see WithSyncLevel
+	syncOnLevel     bool
+	mu              sync.Mutex // serializes updates of state
+	state           atomic.Pointer[loggerState]
+	outMu           *sync.Mutex // shared with derived loggers, serializes writes
+	stats           *levelStats
+	sinks           []sink
 }
 
 var bufferPool = sync.Pool{
@@ -49,168 +106,497 @@ var bufferPool = sync.Pool{
 
 // Convert string to LogLevel
 func logLevelFromString(levelStr string) LogLevel {
-	switch levelStr {
-	case "debug":
-		return DebugLevel
-	case "info":
-		return InfoLevel
-	case "warn":
-		return WarnLevel
-	case "error":
-		return ErrorLevel
-	case "panic":
-		return PanicLevel
-	case "trace":
-		return TraceLevel
+	if level, ok := lookupLogLevel(levelStr); ok {
+		return level
+	}
+	return InfoLevel
+}
+
+// lookupLogLevel converts a string to LogLevel, reporting whether the name is known.
+// Names are matched in any letter case and common aliases are accepted, see ParseLevel.
+func lookupLogLevel(levelStr string) (LogLevel, bool) {
+	switch strings.ToLower(strings.TrimSpace(levelStr)) {
+	case "debug", "dbg", "debu", "0":
+		return DebugLevel, true
+	case "info", "information", "1":
+		return InfoLevel, true
+	case "warn", "warning", "2":
+		return WarnLevel, true
+	case "error", "err", "erro", "3":
+		return ErrorLevel, true
+	case "panic", "pani", "fatal", "crit", "critical", "4":
+		return PanicLevel, true
+	case "trace", "trac", "trc", "5":
+		return TraceLevel, true
 	default:
-		return InfoLevel
+		return InfoLevel, false
 	}
 }
 
-// NewLogger initializes a new logger instance using string for level
+// NewLogger initializes a new logger instance using string for level.
+// It is kept for compatibility; New with options is preferred.
 func NewLogger(levelStr string, output io.Writer, colored bool, logFilePath string) *Logger {
-	level := logLevelFromString(levelStr)
+	return New(
+		WithLevel(logLevelFromString(levelStr)),
+		WithOutput(output),
+		WithColor(colored),
+		WithFile(logFilePath),
+	)
+}
 
-	var logFile *os.File
-	if logFilePath != "" {
-		var err error
-		logFile, err = os.OpenFile(logFilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
-		if err != nil {
-			logFile = nil // Fallback to no file if there is an error
-		}
+// Close closes the log file if it's being used, along with any per-level files and additional sinks.
+// Loggers derived with AddField, Named and the like share these with the logger
+// created by New, and each of them holds a reference to them until it is closed.
+// The outputs are closed with the last reference; closing any other logger only
+// flushes buffered file output and leaves them open for the loggers still using
+// them. Closing a logger twice does nothing.
+func (l *Logger) Close() error {
+	if l.closed.Swap(true) {
+		return nil
 	}
-
-	return &Logger{
-		level:   level,
-		output:  output,
-		file:    logFile,
-		colored: colored,
-		fields:  make(map[string]string),
+	if l.refs.Add(-1) > 0 {
+		return l.Flush()
 	}
-}
 
-// Close closes the log file if it's being used
-func (l *Logger) Close() error {
+	var errs []error
+	if l.reloader != nil {
+		errs = append(errs, l.reloader.Close())
+	}
+	if l.dropReporter != nil {
+		errs = append(errs, l.dropReporter.Close())
+	}
 	if l.file != nil {
-		return l.file.Close()
+		if l.array != nil {
+			errs = append(errs, l.closeArray())
+		}
+		if l.fileBuf != nil {
+			errs = append(errs, l.fileBuf.Close())
+		}
+		if l.gzFile != nil {
+			errs = append(errs, l.gzFile.Close())
+		}
+		errs = append(errs, l.file.Close())
 	}
-	return nil
+	for _, s := range l.sinks {
+		errs = append(errs, s.Close())
+	}
+	for _, f := range l.levelFiles {
+		errs = append(errs, f.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// minLevel returns the minimum level written to the output and sinks
+func (l *Logger) minLevel() LogLevel {
+	return LogLevel(l.level.Load())
+}
+
+func (l *Logger) setLevel(level LogLevel) {
+	l.level.Store(int32(level))
+}
+
+// record is an entry encoded and ready to be written
+type record struct {
+	level   LogLevel
+	entry   Entry  // set only when a formatter, the recent buffer or a sink needs it
+	line    []byte // terminal output, with the color reset when colored
+	plain   []byte // file and sink output, nil when none is configured
+	file    []byte // log file output, plain unless colored files are enabled
+	routes  []route
+	outputs []route
+	raw     bool // written as is by Raw, not an entry
 }
 
 // log handles core logging logic and minimizes allocations
 func (l *Logger) log(level LogLevel, message string, extraFields map[string]string) {
-	logID := atomic.AddInt32(&logIDCounter, 1)
+	l.logAt(time.Time{}, level, message, extraFields, nil)
+}
+
+// logAt logs an entry with the time t, or the current time if t is zero, and
+// runs the panic action for Panic level entries
+func (l *Logger) logAt(t time.Time, level LogLevel, message string, extraFields map[string]string, typed []Field) {
+	l.emit(t, level, message, extraFields, typed)
+	if level == PanicLevel {
+		l.run(l.panicAction, message)
+	}
+}
+
+// emit logs an entry with the time t, or the current time if t is zero
+func (l *Logger) emit(t time.Time, level LogLevel, message string, extraFields map[string]string, typed []Field) {
+	if !l.keep(level) {
+		return
+	}
+	extraFields = l.addCaller(level, extraFields)
 
 	buf := bufferPool.Get().(*[]byte)
 	*buf = (*buf)[:0] // Reset the buffer
 	defer bufferPool.Put(buf)
+	sc := getScratch()
+	defer scratchPool.Put(sc)
 
-	timestamp := time.Now().Format(time.RFC3339)
+	rec := l.prepare(t, level, message, extraFields, typed, buf, sc)
 
-	// Prepare the log message with ID first
-	*buf = append(*buf, "ID:"...)
-	*buf = strconv.AppendInt(*buf, int64(logID), 10)
-	*buf = append(*buf, ' ') // Space after ID
+	l.outMu.Lock()
+	l.write(&rec)
+	l.outMu.Unlock()
+}
 
-	// Prepare the log level and timestamp after ID
-	if level == DebugLevel || level == InfoLevel {
-		*buf = append(*buf, getColor(level)...)
-		*buf = append(*buf, logLevelStrings[level]...)
-		*buf = append(*buf, "\033[0m"...)
+// keep reports whether an entry at level should be encoded, counting those
+// that are sampled out or only written to io.Discard
+func (l *Logger) keep(level LogLevel) bool {
+	if l.sampler != nil && !l.sampler.keep(level) {
+		atomic.AddUint64(&l.stats.sampled, 1)
+		return false
+	}
+	if l.discards() {
+		l.stats.inc(level) // Stats still count entries nobody sees
+		return false
+	}
+	return true
+}
+
+// addCaller returns extraFields with the caller fields for level added, if enabled
+func (l *Logger) addCaller(level LogLevel, extraFields map[string]string) map[string]string {
+	caller := l.caller
+	if level == TraceLevel && caller == CallerOff {
+		caller = l.traceCaller
+	}
+	if caller == CallerOff {
+		return extraFields
+	}
+	return l.withCaller(caller, extraFields)
+}
+
+// discards reports whether entries would only be written to io.Discard, so
+// encoding them can be skipped
+func (l *Logger) discards() bool {
+	if l.output != io.Discard || l.file != nil || len(l.levelFiles) > 0 || len(l.sinks) > 0 {
+		return false
+	}
+	st := l.loadState()
+	return len(st.routes) == 0 && len(st.outputs) == 0 && st.recent == nil
+}
+
+// prepare assigns the log ID and encodes the entry into buf, timestamped now unless t is set
+func (l *Logger) prepare(t time.Time, level LogLevel, message string, extraFields map[string]string, typed []Field, buf *[]byte, sc *scratch) record {
+	logID := atomic.AddInt32(&logIDCounter, 1)
+	l.stats.inc(level)
+
+	now := t
+	if now.IsZero() {
+		now = l.clock.Now()
+	}
+	if l.location != nil {
+		now = now.In(l.location)
+	} else if l.utc {
+		now = now.UTC()
+	}
+	var timestamp string
+	if l.elapsed > 0 {
+		timestamp = formatElapsed(now.Sub(l.start), l.elapsed)
 	} else {
-		*buf = append(*buf, getColor(level)...)
-		*buf = append(*buf, logLevelStrings[level]...)
+		start := len(sc.arena)
+		sc.arena = appendTimestamp(sc.arena, now, l.timeFormat)
+		timestamp = sc.arenaString(start)
 	}
-	*buf = append(*buf, ' ')
-	*buf = append(*buf, timestamp...)
-	*buf = append(*buf, ' ')
-	*buf = append(*buf, message...)
 
-	// Append fields directly from the logger and extra fields
-	l.mu.Lock()
-	if len(l.fields) > 0 || len(extraFields) > 0 {
-		*buf = append(*buf, ',')
+	st := l.loadState()
+	fields, fieldColors, recent, routes, outputs := st.fields, st.fieldColors, st.recent, st.routes, st.outputs
+	if len(typed) > 0 {
+		fields = l.mergeTyped(sc, fields, typed)
 	}
 
-	for key, value := range l.fields {
-		*buf = append(*buf, ' ')
-		*buf = append(*buf, key...)
-		*buf = append(*buf, ':', ' ', '"')
-		*buf = append(*buf, value...)
-		*buf = append(*buf, '"')
+	if len(l.redactors) > 0 {
+		message = redact(l.redactors, message)
+		fields = redactFieldList(l.redactors, fields)
+		extraFields = redactFields(l.redactors, extraFields)
+	}
+	fields = shadowFields(fields, extraFields)
+
+	var entry Entry
+	if l.formatter != nil || recent != nil || len(l.sinks) > 0 {
+		entry = Entry{ID: logID, Level: level, Time: now, Message: message, Fields: mergeFields(fields, extraFields)}
+		if len(typed) > 0 {
+			for k, v := range entry.Fields {
+				entry.Fields[k] = strings.Clone(v) // Typed values point into the scratch arena
+			}
+		}
 	}
 
-	for key, value := range extraFields {
-		*buf = append(*buf, ' ')
-		*buf = append(*buf, key...)
-		*buf = append(*buf, ':', ' ', '"')
-		*buf = append(*buf, value...)
-		*buf = append(*buf, '"')
+	text := l.formatter == nil && l.format == FormatText
+	if text {
+		message = formatMultiline(&l.enc, l.multiline, message, level, timestamp, logID, l.lineEnding)
+	}
+	termFields, termExtra := orderFields(l.termOrder, fields, extraFields)
+	switch {
+	case l.formatter != nil:
+		*buf = append(*buf, l.formatter.Format(entry)...)
+	case !text:
+		*buf = l.appendStructured(*buf, level, now, timestamp, message, termFields, termExtra, logID)
+	default:
+		if !l.colored {
+			fieldColors = nil
+		}
+		*buf = appendTerminalMessage(*buf, &l.enc, level, timestamp, message, termFields, termExtra, fieldColors, logID)
+	}
+	*buf = withLineEnding(*buf, l.lineEnding)
+
+	if recent != nil {
+		recent.add(entry)
 	}
-	l.mu.Unlock()
 
-	*buf = append(*buf, '\n')
+	// Plain form of the message shared by the files and sinks
+	var plain []byte
+	if l.file != nil || len(l.sinks) > 0 || len(l.levelFiles) > 0 || len(outputs) > 0 {
+		fileFields, fileExtra := orderFields(l.fileOrder, fields, extraFields)
+		switch {
+		case text:
+			plain = withLineEnding(buildLogMessage(&l.enc, level, timestamp, message, fileFields, fileExtra, false, logID), l.lineEnding)
+		case l.fileOrder != l.termOrder && l.formatter == nil:
+			plain = withLineEnding(l.appendStructured(nil, level, now, timestamp, message, fileFields, fileExtra, logID), l.lineEnding)
+		default:
+			plain = *buf
+		}
+	}
+
+	// WARN and ERRO lines leave their color open, append the reset so the line
+	// and reset go out in a single write. Colored files always get the reset.
+	n := len(*buf)
+	if text && l.enc.colorsLine(level) {
+		*buf = append(*buf, "\033[0m"...)
+	}
+	line := *buf
+	if !l.colored && !l.enc.lineColor {
+		line = line[:n]
+	}
+	file := plain
+	if l.colorFile && text {
+		file = *buf
+	}
+
+	return record{level: level, entry: entry, line: line, plain: plain, file: file, routes: routes, outputs: outputs}
+}
+
+// write sends a prepared record to the file, sinks and terminal, must be called with outMu held
+func (l *Logger) write(rec *record) {
+	level := rec.level
 
 	// Always write to the file, if it's not nil
 	if l.file != nil {
-		logMessage := buildLogMessage(level, timestamp, message, l.fields, extraFields, false, logID)
-		_, _ = l.file.Write(logMessage)
+		var err error
+		switch {
+		case l.array != nil && rec.raw:
+		case l.array != nil:
+			err = l.writeArrayElement(rec.file)
+		default:
+			err = l.writeFileLine(rec.file)
+		}
+		if err != nil {
+			l.reportError(err)
+		}
+	}
+	for _, f := range l.levelFiles {
+		if f.matches(level) {
+			if err := f.write(rec.file); err != nil {
+				l.reportError(err)
+			}
+		}
+	}
+	if l.syncOnLevel && level >= l.syncLevel && level <= PanicLevel {
+		l.syncFiles(level)
 	}
 
-	// Write to the terminal (with colors and filtering by log level)
-	if level >= l.level {
-		_, _ = l.output.Write(*buf)
+	for _, o := range rec.outputs {
+		if level.atLeast(o.minLevel) {
+			if _, err := o.output.Write(rec.plain); err != nil {
+				l.reportError(err)
+			}
+		}
+	}
+
+	// Forward to additional sinks, filtered by log level like the terminal
+	minLevel := l.minLevel()
+	if len(l.sinks) > 0 && level >= minLevel && !rec.raw {
+		for _, s := range l.sinks {
+			s.write(rec.entry, rec.plain)
+		}
+	}
 
-		// Reset color after writing the full log line for WARN and ERRO
-		if (level == WarnLevel || level == ErrorLevel) && l.colored {
-			_, _ = l.output.Write([]byte("\033[0m"))
+	// Write to the terminal (with colors and filtering by log level)
+	if level >= minLevel {
+		routed, ok := routeFor(rec.routes, level)
+		if !ok {
+			l.writeOutput(l.output, rec)
+			return
+		}
+		for _, r := range rec.routes {
+			if r.minLevel == routed {
+				l.writeOutput(r.output, rec)
+			}
 		}
 	}
 }
 
-// buildLogMessage constructs a log message for writing to file
-func buildLogMessage(level LogLevel, timestamp, message string, fields, extraFields map[string]string, colored bool, logID int32) []byte {
-	var logBuf []byte
-	logBuf = append(logBuf, "ID:"...) // Append ID first
-	logBuf = strconv.AppendInt(logBuf, int64(logID), 10)
-	logBuf = append(logBuf, ' ') // Space after ID
-
-	if colored {
-		logBuf = append(logBuf, getColor(level)...)
-		logBuf = append(logBuf, logLevelStrings[level]...)
-		logBuf = append(logBuf, "\033[0m"...)
+// writeFileLine writes a line to the log file, with its hash if the lines are chained
+func (l *Logger) writeFileLine(line []byte) error {
+	if l.chain != nil {
+		return l.writeChained(line)
+	}
+	return l.writeFile(line)
+}
+
+// writeFile writes p to the log file, through the buffer if there is one
+func (l *Logger) writeFile(p []byte) error {
+	if l.fileBuf != nil {
+		return l.fileBuf.write(p)
+	}
+	if l.gzFile != nil {
+		_, err := l.gzFile.Write(p)
+		return err
+	}
+	_, err := l.file.Write(p)
+	return err
+}
+
+// writeOutput writes the terminal line of rec to w in a single call
+func (l *Logger) writeOutput(w io.Writer, rec *record) {
+	if _, err := w.Write(rec.line); err != nil {
+		l.reportError(err)
+	}
+}
+
+// reportError passes a failed write to the error handler, if one is set
+func (l *Logger) reportError(err error) {
+	if l.onError != nil {
+		l.onError(err)
+	}
+}
+
+// appendStructured appends an entry in the JSON, GELF or CSV format of the logger to dst
+func (l *Logger) appendStructured(dst []byte, level LogLevel, now time.Time, timestamp, message string, fields []field, extraFields map[string]string, logID int32) []byte {
+	switch l.format {
+	case FormatJSON:
+		return appendJSONMessage(dst, &l.enc, level, timestamp, message, fields, extraFields, logID)
+	case FormatGELF:
+		return appendGELFMessage(dst, &l.enc, level, now, message, fields, extraFields, logID)
+	default:
+		return appendCSVMessage(dst, &l.enc, level, timestamp, message, fields, extraFields, logID)
+	}
+}
+
+// appendTerminalMessage appends a log message for the terminal to dst, wrapping
+// the values of fields listed in fieldColors in their color
+func appendTerminalMessage(dst []byte, enc *encoding, level LogLevel, timestamp, message string, fields []field, extraFields, fieldColors map[string]string, logID int32) []byte {
+	// Prepare the ID, level, timestamp and message as laid out, leaving the
+	// level color open for everything but DEBU, INFO and TRAC, or starting it before
+	// the line when whole lines are colored
+	if enc.lineColor && !enc.errorHighlight {
+		dst = append(dst, enc.color(level)...)
+		dst = enc.appendLayout(dst, level, timestamp, message, logID, false, false)
 	} else {
-		logBuf = append(logBuf, logLevelStrings[level]...)
+		dst = enc.appendLayout(dst, level, timestamp, message, logID, true, !enc.colorsLine(level))
 	}
-	logBuf = append(logBuf, ' ')
-	logBuf = append(logBuf, timestamp...)
-	logBuf = append(logBuf, ' ')
-	logBuf = append(logBuf, message...)
 
 	if len(fields) > 0 || len(extraFields) > 0 {
-		logBuf = append(logBuf, ',')
+		dst = append(dst, enc.fieldSep...)
 	}
 
-	for key, value := range fields {
+	for _, f := range fields {
+		key, value := f.key, f.value
+		dst = append(dst, ' ')
+		dst = append(dst, key...)
+		dst = append(dst, ':', ' ')
+		dst = enc.appendFieldValue(dst, level, value, enc.fieldColor(key, fieldColors))
+	}
+
+	for key, value := range extraFields {
+		dst = append(dst, ' ')
+		dst = append(dst, key...)
+		dst = append(dst, ':', ' ')
+		dst = enc.appendFieldValue(dst, level, value, enc.fieldColor(key, fieldColors))
+	}
+
+	dst = append(dst, '\n')
+	return dst
+}
+
+// appendColoredValue appends value wrapped in color, then restores the level
+// color for lines that are colored up to the end
+func (e *encoding) appendColoredValue(dst []byte, level LogLevel, value, color string) []byte {
+	if color == "" {
+		return append(dst, value...)
+	}
+	dst = append(dst, color...)
+	dst = append(dst, value...)
+	dst = append(dst, "\033[0m"...)
+	if e.colorsLine(level) {
+		dst = append(dst, e.color(level)...)
+	}
+	return dst
+}
+
+// fieldColor returns the terminal color of the value of the field key
+func (e *encoding) fieldColor(key string, fieldColors map[string]string) string {
+	if color := fieldColors[key]; color != "" {
+		return color
+	}
+	if e.errorHighlight && key == "error" {
+		return errorColor
+	}
+	return ""
+}
+
+// SetFieldColor colors the value of fields named key with the given ANSI escape
+// sequence (e.g. "\033[31m" for red) on the terminal when colored output is enabled.
+// An empty color removes the setting. Loggers derived afterwards inherit it.
+func (l *Logger) SetFieldColor(key, color string) {
+	l.updateState(func(s *loggerState) {
+		fieldColors := copyFields(s.fieldColors, 1)
+		if color == "" {
+			delete(fieldColors, key)
+		} else {
+			fieldColors[key] = color
+		}
+		s.fieldColors = fieldColors
+	})
+}
+
+// buildLogMessage constructs a log message for writing to file
+func buildLogMessage(enc *encoding, level LogLevel, timestamp, message string, fields []field, extraFields map[string]string, colored bool, logID int32) []byte {
+	var logBuf []byte
+	logBuf = enc.appendLayout(logBuf, level, timestamp, message, logID, colored, true)
+
+	if len(fields) > 0 || len(extraFields) > 0 {
+		logBuf = append(logBuf, enc.fieldSep...)
+	}
+
+	for _, f := range fields {
+		key, value := f.key, f.value
 		logBuf = append(logBuf, ' ')
 		logBuf = append(logBuf, key...)
-		logBuf = append(logBuf, ':', ' ', '"')
-		logBuf = append(logBuf, value...)
-		logBuf = append(logBuf, '"')
+		logBuf = append(logBuf, ':', ' ')
+		logBuf = enc.appendFieldValue(logBuf, level, value, "")
 	}
 
 	for key, value := range extraFields {
 		logBuf = append(logBuf, ' ')
 		logBuf = append(logBuf, key...)
-		logBuf = append(logBuf, ':', ' ', '"')
-		logBuf = append(logBuf, value...)
-		logBuf = append(logBuf, '"')
+		logBuf = append(logBuf, ':', ' ')
+		logBuf = enc.appendFieldValue(logBuf, level, value, "")
 	}
 
 	logBuf = append(logBuf, '\n')
 	return logBuf
 }
 
+// withLineEnding replaces the trailing newline of line with ending
+func withLineEnding(line []byte, ending string) []byte {
+	if ending == "\n" || len(line) == 0 || line[len(line)-1] != '\n' {
+		return line
+	}
+	return append(line[:len(line)-1], ending...)
+}
+
 // getColor returns the ANSI color code for a given log level
 func getColor(level LogLevel) string {
 	switch level {
@@ -222,29 +608,124 @@ func getColor(level LogLevel) string {
 		return "\033[33m" // Yellow
 	case ErrorLevel:
 		return "\033[31m" // Red
+	case TraceLevel:
+		return "\033[90m" // Gray
 	default:
 		return "\033[0m"  // Default
 	}
 }
 
+// clone returns a logger sharing this logger's configuration, with no fields set
+func (l *Logger) clone() *Logger {
+	newLogger := &Logger{
+		level:           l.level,
+		refs:            l.refs,
+		output:          l.output,
+		file:            l.file,
+		colored:         l.colored,
+		colorFile:       l.colorFile,
+		format:          l.format,
+		timeFormat:      l.timeFormat,
+		utc:             l.utc,
+		location:        l.location,
+		start:           l.start,
+		elapsed:         l.elapsed,
+		formatter:       l.formatter,
+		multiline:       l.multiline,
+		lineEnding:      l.lineEnding,
+		name:            l.name,
+		group:           l.group,
+		byteUnits:       l.byteUnits,
+		percentDigits:   l.percentDigits,
+		dropCanceled:    l.dropCanceled,
+		keyPolicy:       l.keyPolicy,
+		redactors:       l.redactors,
+		repanic:         l.repanic,
+		panicAction:     l.panicAction,
+		fatalAction:     l.fatalAction,
+		fmtFallback:     l.fmtFallback,
+		caller:          l.caller,
+		traceCaller:     l.traceCaller,
+		shortCallerFunc: l.shortCallerFunc,
+		termOrder:       l.termOrder,
+		fileOrder:       l.fileOrder,
+		extractors:      l.extractors,
+		enc:             l.enc,
+		levelFiles:      l.levelFiles,
+		onError:         l.onError,
+		sampler:         l.sampler,
+		reloader:        l.reloader,
+		dropReporter:    l.dropReporter,
+		fileBuf:         l.fileBuf,
+		gzFile:          l.gzFile,
+		errChain:        l.errChain,
+		chain:           l.chain,
+		clock:           l.clock,
+		array:           l.array,
+		syncLevel:       l.syncLevel,
+		syncOnLevel:     l.syncOnLevel,
+		outMu:           l.outMu,
+		stats:           l.stats,
+		sinks:           l.sinks,
+	}
+
+	l.refs.Add(1)
+
+	s := l.loadState()
+	newLogger.state.Store(&loggerState{fieldColors: s.fieldColors, recent: s.recent, routes: s.routes, outputs: s.outputs})
+	return newLogger
+}
+
 // AddField adds a field to the logger and returns a new logger instance
 func (l *Logger) AddField(key string, value interface{}) *Logger {
-	newLogger := &Logger{
-		level:   l.level,
-		output:  l.output,
-		file:    l.file,
-		colored: l.colored,
-		fields:  make(map[string]string),
+	key, ok := l.fieldKey(key)
+	if !ok {
+		return l
+	}
+	newLogger := l.withField(key, valueToString(value))
+	if l.errChain > 0 {
+		st := newLogger.loadState()
+		st.fields = l.appendErrorChain(st.fields, key, value)
 	}
+	return newLogger
+}
 
-	l.mu.Lock()
-	for k, v := range l.fields {
-		newLogger.fields[k] = v
+// withField returns a new logger with the field key set to value
+func (l *Logger) withField(key, value string) *Logger {
+	newLogger := l.clone()
+
+	// The new logger is not shared yet, so its state can be set in place
+	newLogger.loadState().fields = setField(l.loadState().fields, key, value)
+
+	return newLogger
+}
+
+// loggerNameKey is the field holding the name set with Named
+const loggerNameKey = "logger"
+
+// Named returns a child logger whose entries carry a "logger" field with its name.
+// Names are joined with dots when chained, e.g. Named("http").Named("router")
+// logs logger: "http.router".
+func (l *Logger) Named(name string) *Logger {
+	fullName := name
+	if l.name != "" {
+		fullName = l.name + "." + name
 	}
-	l.mu.Unlock()
 
-	newLogger.fields[key] = valueToString(value)
+	newLogger := l.withField(loggerNameKey, fullName)
+	newLogger.name = fullName
+	return newLogger
+}
 
+// MergeFields returns a new logger with this logger's configuration and the fields
+// of both loggers. When both set the same key, the value from other wins.
+func (l *Logger) MergeFields(other *Logger) *Logger {
+	newLogger := l.clone()
+	fields := l.loadState().fields
+	for _, f := range other.loadState().fields {
+		fields = setField(fields, f.key, f.value)
+	}
+	newLogger.loadState().fields = fields
 	return newLogger
 }
 
@@ -253,8 +734,8 @@ func valueToString(value interface{}) string {
 	switch v := value.(type) {
 	case string:
 		return v
-	case int:
-		return strconv.Itoa(v)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr:
+		return integerToString(v)
 	case float64:
 		return floatToString(v)
 	case bool:
@@ -262,8 +743,20 @@ func valueToString(value interface{}) string {
 			return "true"
 		}
 		return "false"
+	case []byte:
+		return bytesToString(v)
+	case error:
+		if isNilPointer(v) {
+			return "nil"
+		}
+		return v.Error()
+	case stringer:
+		if isNilPointer(v) {
+			return "nil"
+		}
+		return v.String()
 	default:
-		return "unknown"
+		return compositeToString(value)
 	}
 }
 
@@ -288,44 +781,119 @@ func floatToString(f float64) string {
 }
 
 // Log methods for different levels
-func (l *Logger) Info(message string)  { l.log(InfoLevel, message, nil) }
-func (l *Logger) Warn(message string)  { l.log(WarnLevel, message, nil) }
-func (l *Logger) Error(message string) { l.log(ErrorLevel, message, nil) }
-func (l *Logger) Panic(message string) { l.log(PanicLevel, message, nil) }
-func (l *Logger) Debug(message string) { l.log(DebugLevel, message, nil) }
-func (l *Logger) Trace(message string) { l.log(TraceLevel, message, nil) }
+func (l *Logger) Info(message string, fields ...Field)  { l.Log(InfoLevel, message, fields...) }
+func (l *Logger) Warn(message string, fields ...Field)  { l.Log(WarnLevel, message, fields...) }
+func (l *Logger) Error(message string, fields ...Field) { l.Log(ErrorLevel, message, fields...) }
+func (l *Logger) Panic(message string, fields ...Field) { l.Log(PanicLevel, message, fields...) }
+func (l *Logger) Debug(message string, fields ...Field) { l.Log(DebugLevel, message, fields...) }
+func (l *Logger) Trace(message string, fields ...Field) { l.Log(TraceLevel, message, fields...) }
+
+// Log logs message at level, for when the level is only known at run time,
+// with the typed fields added to the logger's own, see Field
+func (l *Logger) Log(level LogLevel, message string, fields ...Field) {
+	l.logAt(time.Time{}, level, message, nil, fields)
+}
+
+// Logf formats and logs a message at level, e.g. a level picked from an HTTP status code
+func (l *Logger) Logf(level LogLevel, format string, args ...interface{}) {
+	l.log(level, l.sprintf(format, args...), nil)
+}
+
+// LogEntry logs a prebuilt entry, with its fields added to the logger's own. The
+// entry's time is used if set; its ID is ignored and a new one is assigned so
+// IDs stay sequential.
+func (l *Logger) LogEntry(entry Entry) {
+	l.logAt(entry.Time, entry.Level, entry.Message, entry.Fields, nil)
+}
+
+// Log methods joining their arguments with spaces, like log.Println
+func (l *Logger) Infoln(args ...interface{})  { l.log(InfoLevel, joinArgs(args), nil) }
+func (l *Logger) Warnln(args ...interface{})  { l.log(WarnLevel, joinArgs(args), nil) }
+func (l *Logger) Errorln(args ...interface{}) { l.log(ErrorLevel, joinArgs(args), nil) }
+func (l *Logger) Panicln(args ...interface{}) { l.log(PanicLevel, joinArgs(args), nil) }
+func (l *Logger) Debugln(args ...interface{}) { l.log(DebugLevel, joinArgs(args), nil) }
+func (l *Logger) Traceln(args ...interface{}) { l.log(TraceLevel, joinArgs(args), nil) }
+
+// joinArgs converts args with valueToString and joins them with spaces
+func joinArgs(args []interface{}) string {
+	var b []byte
+	for i, arg := range args {
+		if i > 0 {
+			b = append(b, ' ')
+		}
+		b = append(b, valueToString(arg)...)
+	}
+	return string(b)
+}
 
 // Log methods for different levels
-func (l *Logger) Infof(format string, args ...interface{})  { l.log(InfoLevel, formatMessage(format, args...), nil) }
-func (l *Logger) Debugf(format string, args ...interface{}) { l.log(DebugLevel, formatMessage(format, args...), nil) }
-func (l *Logger) Warnf(format string, args ...interface{})  { l.log(WarnLevel, formatMessage(format, args...), nil) }
-func (l *Logger) Errorf(format string, args ...interface{}) { l.log(ErrorLevel, formatMessage(format, args...), nil) }
-func (l *Logger) Panicf(format string, args ...interface{}) { l.log(PanicLevel, formatMessage(format, args...), nil) }
-func (l *Logger) Tracef(format string, args ...interface{}) { l.log(TraceLevel, formatMessage(format, args...), nil) }
-
-// formatMessage is a custom implementation of string formatting
+func (l *Logger) Infof(format string, args ...interface{}) {
+	l.log(InfoLevel, l.sprintf(format, args...), nil)
+}
+func (l *Logger) Debugf(format string, args ...interface{}) {
+	l.log(DebugLevel, l.sprintf(format, args...), nil)
+}
+func (l *Logger) Warnf(format string, args ...interface{}) {
+	l.log(WarnLevel, l.sprintf(format, args...), nil)
+}
+func (l *Logger) Errorf(format string, args ...interface{}) {
+	l.log(ErrorLevel, l.sprintf(format, args...), nil)
+}
+func (l *Logger) Panicf(format string, args ...interface{}) {
+	l.log(PanicLevel, l.sprintf(format, args...), nil)
+}
+func (l *Logger) Tracef(format string, args ...interface{}) {
+	l.log(TraceLevel, l.sprintf(format, args...), nil)
+}
+// formatMessage is a custom implementation of string formatting. Like fmt, a verb
+// without an argument is written as %!d(MISSING) and %f with a value that is not
+// a float as %!f(int=3), so a wrong call never panics.
 func formatMessage(format string, args ...interface{}) string {
     var result string
     argIndex := 0
     for i := 0; i < len(format); i++ {
         if format[i] == '%' && i+1 < len(format) {
-            switch format[i+1] {
-            case 's':
-                result += valueToString(args[argIndex])
-                argIndex++
-                i++ // Skip the format specifier
+            verb := format[i+1]
+            switch verb {
+            case 's', 'v', 'd', 'f', 'b', 'o':
+            default:
+                continue
+            }
+            i++ // Skip the format specifier
+            if argIndex >= len(args) {
+                result += "%!" + string(verb) + "(MISSING)"
+                continue
+            }
+            arg := args[argIndex]
+            argIndex++
+            switch verb {
+            case 's', 'v':
+                result += durationToString(arg)
             case 'd':
-                result += strconv.Itoa(args[argIndex].(int))
-                argIndex++
-                i++ // Skip the format specifier
+                result += integerToString(arg)
             case 'f':
-                result += floatToString(args[argIndex].(float64))
-                argIndex++
-                i++ // Skip the format specifier
+                result += formatFloatArg(arg)
+            case 'b':
+                result += formatInteger(arg, 2)
+            case 'o':
+                result += formatInteger(arg, 8)
             }
         } else {
             result += string(format[i])
         }
     }
     return result
+}
+
+// formatFloatArg formats arg for %f, or names its type like fmt if it is not a float
+func formatFloatArg(arg interface{}) string {
+    switch v := arg.(type) {
+    case float64:
+        return floatToString(v)
+    case float32:
+        return floatToString(float64(v))
+    case nil:
+        return "%!f(<nil>)"
+    }
+    return "%!f(" + reflect.TypeOf(arg).String() + "=" + valueToString(arg) + ")"
 }
\ No newline at end of file
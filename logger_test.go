@@ -0,0 +1,370 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testTime is the time of the fixed clock used by tests
+var testTime = time.Date(2024, 10, 10, 10, 24, 44, 0, time.UTC)
+
+// fixedClock is a Clock that always returns t
+type fixedClock struct {
+	t time.Time
+}
+
+func (c *fixedClock) Now() time.Time { return c.t }
+
+// newTestLogger returns a logger writing uncolored terminal lines, timestamped
+// with testTime in UTC, to the returned buffer. opts are applied on top.
+func newTestLogger(opts ...Option) (*Logger, *bytes.Buffer) {
+	var out bytes.Buffer
+	defaults := []Option{WithOutput(StripANSI(&out)), WithClock(&fixedClock{testTime}), WithUTC(true)}
+	return New(append(defaults, opts...)...), &out
+}
+
+// lines returns the lines written to out, without line endings
+func lines(out *bytes.Buffer) []string {
+	s := strings.TrimSuffix(out.String(), "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// nopWriter discards writes without being io.Discard, so entries are still encoded
+type nopWriter struct{}
+
+func (nopWriter) Write(p []byte) (int, error) { return len(p), nil }
+
+func TestFieldlessLoggerHasNoFieldMap(t *testing.T) {
+	logger := New(WithOutput(nopWriter{}))
+	if fields := logger.loadState().fields; fields != nil {
+		t.Errorf("fields = %v, want none allocated", fields)
+	}
+	if logger.AddField("k", "v").loadState().fields == nil {
+		t.Error("AddField did not allocate the fields")
+	}
+}
+
+func TestFieldlessAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("sync.Pool allocates under -race")
+	}
+	logger := New(WithOutput(nopWriter{}), WithColor(true))
+	if allocs := testing.AllocsPerRun(100, func() { logger.Warn("hello") }); allocs != 0 {
+		t.Errorf("fieldless entry allocates %v times, want 0", allocs)
+	}
+}
+
+func BenchmarkFieldless(b *testing.B) {
+	logger := New(WithOutput(nopWriter{}))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Info("request served")
+	}
+}
+
+// chunkWriter records each Write call separately
+type chunkWriter struct {
+	chunks []string
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	w.chunks = append(w.chunks, string(p))
+	return len(p), nil
+}
+
+func TestConcurrentWritesShareBuffer(t *testing.T) {
+	var out bytes.Buffer // not safe for concurrent use on its own
+	logger := New(WithOutput(&out), WithColor(true))
+	derived := logger.AddField("derived", "yes")
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			l := logger
+			if g%2 == 1 {
+				l = derived
+			}
+			for i := 0; i < 200; i++ {
+				l.Warn(fmt.Sprintf("goroutine %d entry %d", g, i))
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	var stripped strings.Builder
+	_, _ = StripANSI(&stripped).Write(out.Bytes())
+	got := strings.Split(strings.TrimSuffix(stripped.String(), "\n"), "\n")
+	if len(got) != 8*200 {
+		t.Fatalf("wrote %d lines, want %d", len(got), 8*200)
+	}
+	for _, line := range got {
+		if !strings.HasPrefix(line, "ID:") || strings.Count(line, "goroutine") != 1 || strings.Count(line, "WARN") != 1 {
+			t.Fatalf("mangled line %q", line)
+		}
+	}
+}
+
+func TestLineWrittenInOneCall(t *testing.T) {
+	w := &chunkWriter{}
+	logger := New(WithOutput(w), WithColor(true))
+	logger.Warn("one")
+	logger.Info("two")
+	if len(w.chunks) != 2 || !strings.HasSuffix(w.chunks[0], "one\n\033[0m") {
+		t.Errorf("writes = %q, want one per line with its color reset", w.chunks)
+	}
+}
+
+func TestDiscardKeepsSideEffects(t *testing.T) {
+	logger := New(WithOutput(io.Discard))
+	logger.Info("counted")
+	if got := logger.Stats()[InfoLevel]; got != 1 {
+		t.Errorf("Stats()[InfoLevel] = %d, want 1", got)
+	}
+
+	logger = New(WithOutput(io.Discard), WithRecent(5))
+	logger.Info("kept")
+	if recent := logger.Recent(); len(recent) != 1 || recent[0].Message != "kept" {
+		t.Errorf("Recent() = %v, want the entry kept", recent)
+	}
+}
+
+func BenchmarkDiscard(b *testing.B) {
+	for _, bench := range []struct {
+		name   string
+		output io.Writer
+	}{
+		{"io.Discard", io.Discard},
+		{"encoded", nopWriter{}},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			logger := New(WithOutput(bench.output)).AddField("service", "api")
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				logger.Info("request served", Int("status", 200))
+			}
+		})
+	}
+}
+
+func TestNamed(t *testing.T) {
+	logger, out := newTestLogger()
+	http := logger.Named("http")
+	http.Named("router").Named("v2").Info("routed")
+	http.Info("served")
+	logger.Info("root")
+
+	got := lines(out)
+	if len(got) != 3 {
+		t.Fatalf("got lines %q, want 3", got)
+	}
+	for i, want := range []string{`routed, logger: "http.router.v2"`, `served, logger: "http"`} {
+		if !strings.HasSuffix(got[i], want) {
+			t.Errorf("line %q, want suffix %q", got[i], want)
+		}
+	}
+	if strings.Contains(got[2], "logger:") {
+		t.Errorf("parent line %q carries a child's name", got[2])
+	}
+}
+
+func TestSetFieldColor(t *testing.T) {
+	var out bytes.Buffer
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger := New(WithOutput(&out), WithColor(true), WithFile(path), WithFieldSeparator(""))
+	logger.SetFieldColor("error", "\033[35m")
+	logger = logger.AddField("error", "denied").AddField("user", "ana")
+
+	logger.Info("failed")
+	if got := out.String(); !strings.Contains(got, ` error: "`+"\033[35m"+`denied`+"\033[0m"+`" user: "ana"`) {
+		t.Errorf("line %q, want only the error value wrapped in its color", got)
+	}
+
+	// Lines colored to the end get their level color back after the value
+	out.Reset()
+	logger.Warn("failed")
+	if got := out.String(); !strings.Contains(got, "\033[35mdenied\033[0m\033[33m\"") {
+		t.Errorf("line %q, want the level color restored after the value", got)
+	}
+
+	_ = logger.Close()
+	if data, _ := os.ReadFile(path); strings.ContainsRune(string(data), 0x1b) {
+		t.Errorf("file %q is colored", data)
+	}
+
+	var plainOut bytes.Buffer
+	plain := New(WithOutput(&plainOut))
+	plain.SetFieldColor("error", "\033[35m")
+	plain.AddField("error", "denied").Info("failed")
+	if strings.Contains(plainOut.String(), "\033[35m") {
+		t.Errorf("line %q has the field color without WithColor", plainOut.String())
+	}
+}
+
+func TestMergeFields(t *testing.T) {
+	logger, out := newTestLogger()
+	a := logger.AddField("service", "api").AddField("region", "eu")
+	b := logger.AddField("region", "us").AddField("request", "r1")
+
+	merged := a.MergeFields(b)
+	merged.Info("merged")
+	a.Info("a")
+
+	got := lines(out)
+	if want := `merged, service: "api" region: "us" request: "r1"`; !strings.HasSuffix(got[0], want) {
+		t.Errorf("line %q, want suffix %q", got[0], want)
+	}
+	if want := `a, service: "api" region: "eu"`; !strings.HasSuffix(got[1], want) {
+		t.Errorf("line %q, want the original logger unchanged", got[1])
+	}
+}
+
+func TestLogEntryMatchesConvenienceMethods(t *testing.T) {
+	methods := map[LogLevel]func(*Logger, string, ...Field){
+		DebugLevel: (*Logger).Debug,
+		InfoLevel:  (*Logger).Info,
+		WarnLevel:  (*Logger).Warn,
+		ErrorLevel: (*Logger).Error,
+		PanicLevel: (*Logger).Panic,
+		TraceLevel: (*Logger).Trace,
+	}
+	for level, method := range methods {
+		logger, out := newTestLogger(WithLevel(DebugLevel), WithTraceCaller(CallerOff), WithLayout("{level} {time} {msg}"))
+		method(logger.AddField("k", "v"), "same")
+		logger.Log(level, "same", String("k", "v"))
+		logger.LogEntry(Entry{Level: level, Message: "same", Fields: map[string]string{"k": "v"}})
+
+		got := lines(out)
+		if len(got) != 3 || got[1] != got[0] || got[2] != got[0] {
+			t.Errorf("%v: lines differ: %q", level, got)
+		}
+	}
+
+	// The entry's own time is used, its ID is not
+	logger, out := newTestLogger(WithLayout("{id} {time} {msg}"))
+	logger.LogEntry(Entry{ID: -7, Level: InfoLevel, Time: testTime.Add(-time.Hour), Message: "earlier"})
+	if got := lines(out)[0]; !strings.HasSuffix(got, " 2024-10-10T09:24:44Z earlier") || strings.HasPrefix(got, "-7") {
+		t.Errorf("line %q, want the entry's time and a fresh ID", got)
+	}
+}
+
+func TestLogf(t *testing.T) {
+	logger, out := newTestLogger(WithLevel(WarnLevel))
+	for _, level := range []LogLevel{DebugLevel, InfoLevel, WarnLevel, ErrorLevel} {
+		logger.Logf(level, "status %d", 200+int(level))
+	}
+
+	got := lines(out)
+	want := []string{"WARN 2024-10-10T10:24:44Z status 202", "ERRO 2024-10-10T10:24:44Z status 203"}
+	if len(got) != len(want) {
+		t.Fatalf("lines = %q, want %q", got, want)
+	}
+	for i := range want {
+		if !strings.HasSuffix(got[i], want[i]) {
+			t.Errorf("line %q, want suffix %q", got[i], want[i])
+		}
+	}
+}
+
+func TestCloseChained(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.json.gz")
+	logger := New(WithOutput(io.Discard), WithFormat(FormatJSON), WithJSONArray(true), WithFile(path), WithGzip(true))
+	derived := logger.AddField("k", "v")
+	child := derived.Named("svc")
+
+	// The loggers share the file until the last of them is closed, in any order
+	logger.Info("parent")
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+	derived.Info("derived")
+	if err := derived.Close(); err != nil {
+		t.Fatal(err)
+	}
+	child.Info("child")
+	if err := child.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := child.Close(); err != nil {
+		t.Errorf("second Close = %v, want nil", err)
+	}
+
+	text, err := gunzip(t, path)
+	if err != nil {
+		t.Fatalf("gunzip: %v", err)
+	}
+	var entries []map[string]interface{}
+	if err := json.Unmarshal([]byte(text), &entries); err != nil {
+		t.Fatalf("file %q is not a JSON array: %v", text, err)
+	}
+	if len(entries) != 3 || entries[0]["msg"] != "parent" || entries[1]["msg"] != "derived" || entries[2]["logger"] != "svc" {
+		t.Errorf("entries = %v", entries)
+	}
+}
+
+func TestDerivedClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger, _ := newTestLogger(WithFile(path), WithFileBuffer(4096, time.Hour))
+	derived := logger.AddField("k", "v")
+	derived.Info("from derived")
+	if err := derived.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Closing the derived logger flushed its entry but left the file open
+	data, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(data), "from derived") {
+		t.Errorf("file after derived Close = %q, %v", data, err)
+	}
+	logger.Info("from parent")
+	derived.Info("derived again")
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err = os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"from derived", "from parent", "derived again"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("file %q is missing %q", data, want)
+		}
+	}
+}
+
+func TestInfoln(t *testing.T) {
+	logger, out := newTestLogger(WithLayout("{level} {msg}"))
+	logger.Infoln("x", 1, true)
+	logger.Errorln("failed:", &testError{404}, []int{1, 2}, 1.5)
+	logger.Warnln()
+	logger.Infoln("")
+	logger.Debugln("below the level")
+
+	got := lines(out)
+	want := []string{"INFO x 1 true", "ERRO failed: error 404 [1, 2] 1.50", "WARN ", "INFO "}
+	if len(got) != len(want) {
+		t.Fatalf("lines = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("line %q, want %q", got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import "strings"
+
+// MultilineMode controls how line breaks inside messages are written in the text format
+type MultilineMode int
+
+const (
+	MultilineRaw    MultilineMode = iota // write line breaks as they are
+	MultilineIndent                      // indent continuation lines to align under the message
+	MultilineEscape                      // replace line breaks with \n so each entry stays on one line
+)
+
+// WithMultiline sets how messages containing line breaks are written in the
+// text format. Other formats already escape line breaks.
+func WithMultiline(mode MultilineMode) Option {
+	return func(l *Logger) {
+		l.multiline = mode
+	}
+}
+
+// formatMultiline rewrites the line breaks in message according to mode. Indented
+// lines are terminated with lineEnding and aligned under the start of the message.
+func formatMultiline(enc *encoding, mode MultilineMode, message string, level LogLevel, timestamp string, logID int32, lineEnding string) string {
+	if mode == MultilineRaw || !strings.ContainsAny(message, "\r\n") {
+		return message
+	}
+
+	var sep []byte
+	if mode == MultilineEscape {
+		sep = []byte(`\n`)
+	} else {
+		width := enc.messageOffset(level, timestamp, logID)
+		sep = append([]byte(lineEnding), strings.Repeat(" ", width)...)
+	}
+
+	out := make([]byte, 0, len(message)+len(sep)*2)
+	for i := 0; i < len(message); i++ {
+		switch message[i] {
+		case '\r':
+			if i+1 < len(message) && message[i+1] == '\n' {
+				i++
+			}
+			out = append(out, sep...)
+		case '\n':
+			out = append(out, sep...)
+		default:
+			out = append(out, message[i])
+		}
+	}
+	return string(out)
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMultilineIndent(t *testing.T) {
+	for _, opts := range [][]Option{
+		{WithMultiline(MultilineIndent)},
+		{WithMultiline(MultilineIndent), WithPrefix("[app]")},
+		{WithMultiline(MultilineIndent), WithPrefix("[app]"), WithIDWidth(8), WithLayout("{time} | {level} | {id} | {msg}")},
+	} {
+		logger, out := newTestLogger(opts...)
+		logger.Info("failed:\nstep one\nstep two")
+
+		got := lines(out)
+		if len(got) != 3 {
+			t.Fatalf("got lines %q, want 3", got)
+		}
+		indent := strings.Repeat(" ", strings.Index(got[0], "failed:"))
+		if got[1] != indent+"step one" || got[2] != indent+"step two" {
+			t.Errorf("continuation lines of %q are not aligned under the message", got)
+		}
+	}
+}
+
+func TestMultilineRawAndEscape(t *testing.T) {
+	logger, out := newTestLogger(WithMultiline(MultilineRaw))
+	logger.Info("one\ntwo\nthree")
+	if got := lines(out); len(got) != 3 || got[1] != "two" || got[2] != "three" {
+		t.Errorf("raw: got lines %q", got)
+	}
+
+	logger, out = newTestLogger(WithMultiline(MultilineEscape))
+	logger.Info("one\ntwo\r\nthree")
+	if got := lines(out); len(got) != 1 || !strings.HasSuffix(got[0], `one\ntwo\nthree`) {
+		t.Errorf("escape: got lines %q", got)
+	}
+}
+
+func TestMultilineFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger, out := newTestLogger(WithMultiline(MultilineIndent), WithPrefix("[app]"), WithFile(path))
+	logger.Info("one\ntwo\nthree")
+	_ = logger.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != out.String() {
+		t.Errorf("file %q differs from the terminal %q", data, out.String())
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"encoding/binary"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Framing selects how entries are delimited on a network connection
+type Framing int
+
+const (
+	FramingNewline      Framing = iota // each entry ends with '\n'
+	FramingLengthPrefix                // each entry is preceded by its length as a 4-byte big-endian integer
+)
+
+const (
+	networkQueueSize    = 1024
+	networkDialTimeout  = 5 * time.Second
+	networkWriteTimeout = 5 * time.Second
+	networkMinBackoff   = 100 * time.Millisecond
+	networkMaxBackoff   = 30 * time.Second
+)
+
+// networkSink ships entries to a remote collector from a background goroutine
+type networkSink struct {
+	proto, addr string
+	framing     Framing
+
+	mu      sync.RWMutex // guards closed against sends on a closed queue
+	closed  bool
+	queue   chan []byte
+	stop    chan struct{}
+	done    chan struct{}
+	dropped uint64
+
+	// Used by the run goroutine only
+	conn        net.Conn
+	connClosing bool // conn was dialed while closing
+	backoff     time.Duration
+	gaveUp      bool // the collector was unreachable while closing, remaining frames are dropped
+}
+
+// WithNetwork ships newline-delimited entries at or above the logger's level to a
+// remote collector over proto ("tcp" or "udp"). See WithNetworkFraming.
+func WithNetwork(proto, addr string) Option {
+	return WithNetworkFraming(proto, addr, FramingNewline)
+}
+
+// WithNetworkFraming ships entries to a remote collector using the given framing.
+// Entries are queued and sent in the background, reconnecting with backoff when the
+// connection fails; once the bounded queue is full new entries are dropped instead
+// of blocking the caller. Entries written after Close are dropped as well.
+func WithNetworkFraming(proto, addr string, framing Framing) Option {
+	return withSink(func() sink {
+		s := &networkSink{
+			proto:   proto,
+			addr:    addr,
+			framing: framing,
+			queue:   make(chan []byte, networkQueueSize),
+			stop:    make(chan struct{}),
+			done:    make(chan struct{}),
+			backoff: networkMinBackoff,
+		}
+		go s.run()
+		return s
+	})
+}
+
+func (s *networkSink) write(entry Entry, line []byte) {
+	var frame []byte
+	if s.framing == FramingLengthPrefix {
+		if n := len(line); n > 0 && line[n-1] == '\n' {
+			line = line[:n-1]
+		}
+		frame = make([]byte, 4, 4+len(line))
+		binary.BigEndian.PutUint32(frame, uint32(len(line)))
+		frame = append(frame, line...)
+	} else {
+		frame = append([]byte(nil), line...)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		atomic.AddUint64(&s.dropped, 1)
+		return
+	}
+	select {
+	case s.queue <- frame:
+	default:
+		atomic.AddUint64(&s.dropped, 1)
+	}
+}
+
+func (s *networkSink) droppedEntries() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// run sends queued frames until the queue is closed, counting those it cannot send
+func (s *networkSink) run() {
+	defer close(s.done)
+
+	for frame := range s.queue {
+		if !s.send(frame) {
+			atomic.AddUint64(&s.dropped, 1)
+		}
+	}
+
+	if s.conn != nil {
+		_ = s.conn.Close()
+	}
+}
+
+// send writes frame to the collector, reconnecting with backoff until it
+// succeeds. Once the sink is closing, it connects at most once more and gives
+// up on this and all remaining frames when that fails, so Close does not wait
+// for an unreachable collector.
+func (s *networkSink) send(frame []byte) bool {
+	for !s.gaveUp {
+		if s.conn == nil {
+			closing := s.closing()
+			conn, err := net.DialTimeout(s.proto, s.addr, networkDialTimeout)
+			if err != nil {
+				s.retry(closing)
+				continue
+			}
+			s.conn, s.connClosing = conn, closing
+		}
+
+		_ = s.conn.SetWriteDeadline(time.Now().Add(networkWriteTimeout))
+		if _, err := s.conn.Write(frame); err != nil {
+			_ = s.conn.Close()
+			s.conn = nil
+			s.retry(s.connClosing)
+			continue
+		}
+		s.backoff = networkMinBackoff
+		return true
+	}
+	return false
+}
+
+// retry waits with backoff after a failed attempt, or gives up if the attempt was made while closing
+func (s *networkSink) retry(closing bool) {
+	if closing {
+		s.gaveUp = true
+		return
+	}
+	if s.wait(s.backoff) {
+		s.backoff = min(s.backoff*2, networkMaxBackoff)
+	}
+}
+
+// closing reports whether Close has been called
+func (s *networkSink) closing() bool {
+	select {
+	case <-s.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// wait sleeps for d, returning false early if the sink is being closed
+func (s *networkSink) wait(d time.Duration) bool {
+	select {
+	case <-time.After(d):
+		return true
+	case <-s.stop:
+		return false
+	}
+}
+
+// Close sends what is still queued if the collector is reachable, then closes the connection
+func (s *networkSink) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	close(s.queue)
+	close(s.stop)
+	s.mu.Unlock()
+
+	<-s.done
+	return nil
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// collector accepts TCP connections and keeps everything received on them
+type collector struct {
+	ln   net.Listener
+	mu   sync.Mutex
+	data bytes.Buffer
+	done int // connections read to the end
+}
+
+func newCollector(t *testing.T) *collector {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &collector{ln: ln}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				b, _ := io.ReadAll(conn)
+				c.mu.Lock()
+				c.data.Write(b)
+				c.done++
+				c.mu.Unlock()
+			}()
+		}
+	}()
+	t.Cleanup(func() { _ = ln.Close() })
+	return c
+}
+
+func (c *collector) addr() string { return c.ln.Addr().String() }
+
+// received waits until conns connections were closed by the sink and returns what they sent
+func (c *collector) received(t *testing.T, conns int) []byte {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(time.Millisecond) {
+		c.mu.Lock()
+		done, data := c.done, append([]byte(nil), c.data.Bytes()...)
+		c.mu.Unlock()
+		if done >= conns {
+			return data
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d of %d connections closed", done, conns)
+		}
+	}
+}
+
+func TestNetworkNewlineFraming(t *testing.T) {
+	c := newCollector(t)
+	logger := New(WithOutput(io.Discard), WithFormat(FormatJSON), WithNetwork("tcp", c.addr()))
+	logger.Info("first")
+	logger.Warn("second")
+	logger.Debug("below the level")
+	_ = logger.Close()
+
+	lines := strings.Split(strings.TrimSuffix(string(c.received(t, 1)), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %q", len(lines), lines)
+	}
+	if !strings.Contains(lines[0], `"msg":"first"`) || !strings.Contains(lines[1], `"msg":"second"`) {
+		t.Errorf("unexpected lines %q", lines)
+	}
+}
+
+func TestNetworkLengthPrefixFraming(t *testing.T) {
+	c := newCollector(t)
+	logger := New(WithOutput(io.Discard), WithLayout("{msg}"), WithNetworkFraming("tcp", c.addr(), FramingLengthPrefix))
+	logger.Info("one")
+	logger.Info("three")
+	_ = logger.Close()
+
+	data := c.received(t, 1)
+	var got []string
+	for len(data) >= 4 {
+		n := binary.BigEndian.Uint32(data)
+		got = append(got, string(data[4:4+n]))
+		data = data[4+n:]
+	}
+	if len(got) != 2 || got[0] != "one" || got[1] != "three" {
+		t.Errorf("frames = %q, want [one three]", got)
+	}
+}
+
+func TestNetworkOptionReuse(t *testing.T) {
+	c := newCollector(t)
+	opt := WithNetwork("tcp", c.addr())
+	a := New(WithOutput(io.Discard), WithLayout("{msg}"), opt)
+	b := New(WithOutput(io.Discard), WithLayout("{msg}"), opt)
+
+	_ = a.Close()
+	b.Info("after a closed")
+	_ = b.Close()
+
+	if got := string(c.received(t, 1)); got != "after a closed\n" {
+		t.Errorf("received %q", got)
+	}
+	if d := b.Drops(); d.Sinks != 0 {
+		t.Errorf("b dropped %d entries", d.Sinks)
+	}
+}
+
+func TestNetworkCloseUnreachable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	_ = ln.Close() // Nothing listens on addr anymore
+
+	logger := New(WithOutput(io.Discard), WithNetwork("tcp", addr))
+	const entries = 200
+	for i := 0; i < entries; i++ {
+		logger.Info("lost")
+	}
+
+	start := time.Now()
+	_ = logger.Close()
+	if elapsed := time.Since(start); elapsed > 2*networkDialTimeout {
+		t.Errorf("Close took %v", elapsed)
+	}
+	logger.Info("after close")
+	if d := logger.Drops(); d.Sinks != entries+1 {
+		t.Errorf("Drops().Sinks = %d, want %d", d.Sinks, entries+1)
+	}
+}
@@ -0,0 +1,9 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+//go:build !race
+
+package trolog
+
+const raceEnabled = false
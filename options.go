@@ -0,0 +1,225 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Format selects how log entries are encoded
+type Format int
+
+const (
+	FormatText Format = iota // ID:1 INFO 2024-10-10T10:24:44+06:00 message, key: "value"
+	FormatJSON               // {"id":1,"level":"INFO","time":"...","msg":"message","key":"value"}
+	FormatGELF               // Graylog Extended Log Format, one JSON object per line
+	FormatCSV                // id,level,time,message,fields rows as described in RFC 4180
+)
+
+// Option configures a Logger created with New
+type Option func(*Logger)
+
+// New creates a logger with the given options applied on top of the defaults:
+// info level, text format, RFC3339 timestamps, uncolored output to os.Stdout and no log file
+func New(opts ...Option) *Logger {
+	l := &Logger{
+		level:      new(atomic.Int32),
+		refs:       new(atomic.Int32),
+		output:     os.Stdout,
+		format:     FormatText,
+		timeFormat: time.RFC3339,
+		lineEnding: "\n",
+		enc:        newEncoding(),
+		outMu:      &sync.Mutex{},
+		stats:      &levelStats{},
+
+		percentDigits: defaultPercentDigits,
+		fatalAction:   ActionExit(1),
+		traceCaller:   CallerFile,
+		clock:         systemClock{},
+	}
+
+	l.setLevel(InfoLevel)
+	l.refs.Store(1)
+	if fields := loadGlobalFields(); len(fields) > 0 {
+		l.state.Store(&loggerState{fields: fields})
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	// A nil output disables terminal output, unless entries would go nowhere at all
+	if l.output == nil {
+		if l.file != nil || len(l.levelFiles) > 0 || len(l.sinks) > 0 || len(l.loadState().outputs) > 0 {
+			l.output = io.Discard
+		} else {
+			l.output = os.Stderr
+		}
+	}
+
+	// Level labels are colored on the terminal even without WithColor
+	l.output = prepareColorOutput(l.output)
+
+	var fileWriter io.Writer = l.file
+	if l.file != nil && l.gzip {
+		l.gzFile = newGzipFile(l.file, l.fileBufSize == 0)
+		fileWriter = l.gzFile
+	}
+
+	if l.file != nil && l.jsonArray && l.format == FormatJSON && l.formatter == nil {
+		l.array = &jsonArray{}
+	}
+	if l.file != nil && l.hashChain && l.array == nil {
+		l.chain = l.newHashChain()
+	}
+	if l.format == FormatCSV && l.csvHeader {
+		l.writeCSVHeader()
+	}
+
+	if l.file != nil && l.fileBufSize > 0 {
+		l.fileBuf = newFileBuffer(fileWriter, l.fileBufSize, l.fileFlush, l.onError)
+	}
+
+	if l.elapsed > 0 {
+		l.start = l.clock.Now()
+	}
+	if l.banner != nil {
+		if _, ok := l.banner["start"]; !ok {
+			l.banner["start"] = l.clock.Now().Format(time.RFC3339)
+		}
+		l.log(InfoLevel, bannerMessage, l.banner)
+	}
+	if l.dropReporter != nil {
+		go l.dropReporter.run()
+	}
+	return l
+}
+
+// WithLevel sets the minimum level written to the output
+func WithLevel(level LogLevel) Option {
+	return func(l *Logger) {
+		l.setLevel(level)
+	}
+}
+
+// WithOutput sets the writer that receives terminal output. A nil writer
+// disables terminal output, or falls back to os.Stderr if there is no log file
+// or sink to receive entries either. Entries for io.Discard alone are counted
+// in Stats but not encoded at all.
+func WithOutput(output io.Writer) Option {
+	return func(l *Logger) {
+		l.output = output
+	}
+}
+
+// WithColor enables or disables colored terminal output
+func WithColor(colored bool) Option {
+	return func(l *Logger) {
+		l.colored = colored
+	}
+}
+
+// WithColorFile writes the colored terminal line to the log files instead of
+// the plain one, for files viewed with cat or less -R. Text format only.
+func WithColorFile(colorFile bool) Option {
+	return func(l *Logger) {
+		l.colorFile = colorFile
+	}
+}
+
+// WithFile appends every entry to the file at path. An empty path or a file
+// that cannot be opened leaves file logging disabled.
+func WithFile(path string) Option {
+	return func(l *Logger) {
+		if path == "" {
+			return
+		}
+		logFile, err := openLogFile(path)
+		if err != nil {
+			return // Fallback to no file if there is an error
+		}
+		l.file = logFile
+	}
+}
+
+// openLogFile opens path for appending, creating it if needed
+func openLogFile(path string) (*os.File, error) {
+	return os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
+}
+
+// WithFormat sets the encoding used for both terminal and file output
+func WithFormat(format Format) Option {
+	return func(l *Logger) {
+		l.format = format
+	}
+}
+
+// WithFormatter encodes entries with f for both terminal and file output,
+// taking precedence over WithFormat
+func WithFormatter(f Formatter) Option {
+	return func(l *Logger) {
+		l.formatter = f
+	}
+}
+
+// WithTimeFormat sets the time.Format layout used for timestamps, or one of
+// the epoch formats such as TimeUnixMilli
+func WithTimeFormat(layout string) Option {
+	return func(l *Logger) {
+		l.timeFormat = layout
+		l.elapsed = 0
+		l.enc.epochTime = isEpochLayout(layout)
+	}
+}
+
+// WithUTC writes timestamps in UTC rather than local time
+func WithUTC(utc bool) Option {
+	return func(l *Logger) {
+		l.utc = utc
+	}
+}
+
+// WithCSVHeader writes a header row when the logger is created with FormatCSV.
+// The header is not repeated in a log file that already has content.
+func WithCSVHeader() Option {
+	return func(l *Logger) {
+		l.csvHeader = true
+	}
+}
+
+// WithLineEnding sets the terminator written after each entry, e.g. "\r\n".
+// An empty string keeps the default "\n".
+func WithLineEnding(ending string) Option {
+	return func(l *Logger) {
+		if ending == "" {
+			ending = "\n"
+		}
+		l.lineEnding = ending
+	}
+}
+
+// WithOnError sets a handler called with the error whenever writing a log line
+// to the output, the log file or a per-level file fails, e.g. on a full disk or
+// a broken pipe, and for field keys rejected under KeysStrict. The handler may
+// run while the logger holds its output lock, so it must not log through the
+// same logger.
+func WithOnError(fn func(error)) Option {
+	return func(l *Logger) {
+		l.onError = fn
+	}
+}
+
+// WithRecent keeps the last size entries in memory, see Recent
+func WithRecent(size int) Option {
+	return func(l *Logger) {
+		if size > 0 {
+			l.updateState(func(s *loggerState) { s.recent = newRingBuffer(size) })
+		}
+	}
+}
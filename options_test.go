@@ -0,0 +1,217 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithLevel(t *testing.T) {
+	logger, out := newTestLogger(WithLevel(WarnLevel))
+	logger.Info("hidden")
+	logger.Warn("shown")
+	if got := lines(out); len(got) != 1 || !strings.Contains(got[0], "shown") {
+		t.Errorf("lines = %q, want only the warning", got)
+	}
+}
+
+func TestWithOutput(t *testing.T) {
+	var out bytes.Buffer
+	logger := New(WithOutput(&out))
+	logger.Info("hello")
+	if !strings.Contains(out.String(), "hello") {
+		t.Errorf("output %q does not contain the message", out.String())
+	}
+}
+
+func TestWithColor(t *testing.T) {
+	for _, colored := range []bool{false, true} {
+		var out bytes.Buffer
+		logger := New(WithOutput(&out), WithColor(colored))
+		logger.Warn("careful")
+		if got := strings.HasSuffix(out.String(), "\n\033[0m"); got != colored {
+			t.Errorf("WithColor(%v): line %q ends with a color reset: %v", colored, out.String(), got)
+		}
+	}
+}
+
+func TestWithFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger, _ := newTestLogger(WithFile(path))
+	logger.Info("to the file")
+	_ = logger.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "ID:"; !strings.HasPrefix(string(data), want) || !strings.Contains(string(data), "to the file") {
+		t.Errorf("file content = %q", data)
+	}
+}
+
+func TestWithFormat(t *testing.T) {
+	logger, out := newTestLogger(WithFormat(FormatJSON))
+	logger.Info("structured")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+		t.Fatalf("line %q is not JSON: %v", out.String(), err)
+	}
+	if entry["msg"] != "structured" || entry["level"] != "INFO" {
+		t.Errorf("entry = %v", entry)
+	}
+}
+
+func TestWithTimeFormat(t *testing.T) {
+	logger, out := newTestLogger(WithTimeFormat(time.Kitchen))
+	logger.Info("teatime")
+	if !strings.Contains(out.String(), " 10:24AM teatime") {
+		t.Errorf("line %q does not have the Kitchen timestamp", out.String())
+	}
+}
+
+func TestNewLogger(t *testing.T) {
+	var out bytes.Buffer
+	logger := NewLogger("debug", StripANSI(&out), false, "")
+	logger.Debug("still works")
+	if !strings.Contains(out.String(), "DEBU") || !strings.Contains(out.String(), "still works") {
+		t.Errorf("output = %q", out.String())
+	}
+}
+
+func TestWithLineEnding(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger, out := newTestLogger(WithLineEnding("\r\n"), WithFile(path), WithMultiline(MultilineIndent))
+	logger.Info("one")
+	logger.AddField("k", "v").Warn("two\nthree")
+	_ = logger.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, text := range map[string]string{"terminal": out.String(), "file": string(data)} {
+		if n := strings.Count(text, "\r\n"); n != 3 || strings.Count(text, "\n") != 3 {
+			t.Errorf("%s %q has %d CRLF line endings, want every one of 3 lines to end with CRLF", name, text, n)
+		}
+	}
+
+	for _, format := range []Format{FormatJSON, FormatCSV} {
+		logger, out := newTestLogger(WithLineEnding("\r\n"), WithFormat(format), WithCSVHeader())
+		logger.Info("one")
+		if text := out.String(); strings.Count(text, "\r\n") != strings.Count(text, "\n") || !strings.HasSuffix(text, "\r\n") {
+			t.Errorf("format %v: %q does not use CRLF throughout", format, text)
+		}
+	}
+
+	logger, out = newTestLogger(WithLineEnding(""))
+	logger.Info("default")
+	if text := out.String(); strings.Contains(text, "\r") || !strings.HasSuffix(text, "\n") {
+		t.Errorf("empty ending: %q, want the default newline", text)
+	}
+}
+
+// failingWriter fails every write with err
+type failingWriter struct {
+	err error
+}
+
+func (w failingWriter) Write(p []byte) (int, error) { return 0, w.err }
+
+func TestWithOnError(t *testing.T) {
+	errFull := errors.New("disk full")
+	var reported []error
+	logger := New(
+		WithOutput(failingWriter{errFull}),
+		WithLevelFile(ErrorLevel, ErrorLevel, filepath.Join(t.TempDir(), "missing", "errors.log")),
+		WithOnError(func(err error) { reported = append(reported, err) }),
+	)
+
+	logger.Info("one")
+	logger.Error("two")
+	logger.Error("three")
+
+	// Each failed output write is reported, the level file failing to open only once
+	var writes, opens int
+	for _, err := range reported {
+		switch {
+		case errors.Is(err, errFull):
+			writes++
+		case errors.Is(err, os.ErrNotExist):
+			opens++
+		default:
+			t.Errorf("unexpected error %v", err)
+		}
+	}
+	if writes != 3 || opens != 1 {
+		t.Errorf("reported %d write and %d open errors, want 3 and 1: %v", writes, opens, reported)
+	}
+
+	// Without a handler failures are ignored
+	New(WithOutput(failingWriter{errFull})).Info("ignored")
+}
+
+func TestWithColorFile(t *testing.T) {
+	for _, colorFile := range []bool{false, true} {
+		path := filepath.Join(t.TempDir(), "app.log")
+		logger := New(WithOutput(&bytes.Buffer{}), WithColor(true), WithColorFile(colorFile), WithFile(path))
+		logger.Warn("careful")
+		_ = logger.Close()
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if colored := strings.Contains(string(data), "\033[33mWARN"); colored != colorFile {
+			t.Errorf("WithColorFile(%v): file %q colored: %v", colorFile, data, colored)
+		}
+		if colorFile && !strings.HasSuffix(string(data), "\033[0m") {
+			t.Errorf("colored file %q does not reset the color", data)
+		}
+	}
+}
+
+func TestNilOutput(t *testing.T) {
+	// Without anywhere else to go, entries fall back to os.Stderr
+	stderr, err := os.Create(filepath.Join(t.TempDir(), "stderr"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func(saved *os.File) { os.Stderr = saved }(os.Stderr)
+	os.Stderr = stderr
+
+	NewLogger("info", nil, false, "").Info("to stderr")
+	New(WithOutput(nil)).Error("also to stderr")
+	_ = stderr.Close()
+
+	data, err := os.ReadFile(stderr.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "to stderr") || !strings.Contains(string(data), "also to stderr") {
+		t.Errorf("stderr = %q, want both entries", data)
+	}
+
+	// With a log file, nil disables terminal output
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger := NewLogger("info", nil, false, path)
+	logger.Warn("to the file only")
+	_ = logger.Close()
+	if logger.output != io.Discard {
+		t.Errorf("output = %T, want io.Discard", logger.output)
+	}
+	if data, err := os.ReadFile(path); err != nil || !strings.Contains(string(data), "to the file only") {
+		t.Errorf("file = %q, %v", data, err)
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errNotLogLine = errors.New("trolog: not a log line")
+
+// Parse reads a line written in the default text format back into an entry. The
+// timestamp must be RFC3339, with or without fractional seconds, and colors are
+// ignored. Field values may be written in any QuoteStyle, with escaped quotes and
+// backslashes, and lines written with an empty WithFieldSeparator are read as
+// well. A message that itself ends in what reads as fields, such as
+// `failed, code: 1`, is taken to have them.
+func Parse(line string) (Entry, error) {
+	if strings.IndexByte(line, 0x1b) >= 0 {
+		var b strings.Builder
+		_, _ = StripANSI(&b).Write([]byte(line))
+		line = b.String()
+	}
+	line = strings.TrimRight(line, "\r\n")
+
+	if !strings.HasPrefix(line, "ID:") {
+		return Entry{}, errNotLogLine
+	}
+	idText, rest, _ := strings.Cut(line[len("ID:"):], " ")
+	id, err := strconv.ParseInt(idText, 10, 32)
+	if err != nil {
+		return Entry{}, errors.New("trolog: invalid log ID " + strconv.Quote(idText))
+	}
+
+	label, rest, _ := strings.Cut(rest, " ")
+	level, ok := levelFromLabel(label)
+	if !ok {
+		return Entry{}, errors.New("trolog: unknown level label " + strconv.Quote(label))
+	}
+
+	timestamp, rest, _ := strings.Cut(rest, " ")
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return Entry{}, errors.New("trolog: invalid timestamp " + strconv.Quote(timestamp))
+	}
+
+	message, fields := splitFields(rest)
+	return Entry{ID: int32(id), Level: level, Time: t, Message: message, Fields: fields}, nil
+}
+
+// levelFromLabel returns the level written as label, in any letter case
+func levelFromLabel(label string) (LogLevel, bool) {
+	for level := DebugLevel; level <= TraceLevel; level++ {
+		if strings.EqualFold(label, logLevelStrings[level]) || strings.EqualFold(label, shortLabels[level]) {
+			return level, true
+		}
+	}
+	return InfoLevel, false
+}
+
+// splitFields splits the message from the trailing `, key: "value"` fields, or
+// ` key: "value"` fields without the comma, see WithFieldSeparator
+func splitFields(s string) (string, map[string]string) {
+	for i := strings.IndexAny(s, ", "); i >= 0; {
+		rest := s[i:]
+		if s[i] == ',' {
+			rest = s[i+1:]
+		}
+		if fields, ok := parseFieldList(rest); ok {
+			return s[:i], fields
+		}
+		next := strings.IndexAny(s[i+1:], ", ")
+		if next < 0 {
+			break
+		}
+		i += next + 1
+	}
+	return s, nil
+}
+
+// parseFieldList parses a non-empty sequence of ` key: value` fields making up all of s
+func parseFieldList(s string) (map[string]string, bool) {
+	if s == "" {
+		return nil, false
+	}
+
+	fields := make(map[string]string)
+	for s != "" {
+		key, n := fieldStart(s)
+		if n == 0 {
+			return nil, false
+		}
+		value, m, ok := fieldValue(s[n:])
+		if !ok {
+			return nil, false
+		}
+		fields[key] = value
+		s = s[n+m:]
+	}
+	return fields, true
+}
+
+// fieldStart matches ` key: ` at the start of s, returning the key and the length matched
+func fieldStart(s string) (string, int) {
+	if len(s) < 2 || s[0] != ' ' {
+		return "", 0
+	}
+	colon := strings.Index(s, ": ")
+	if colon < 2 || strings.ContainsAny(s[1:colon], " :\"'") {
+		return "", 0
+	}
+	return s[1:colon], colon + len(": ")
+}
+
+// fieldValue reads the value at the start of s up to the end or the next
+// field, returning it unescaped with the length read. Values quoted with " or '
+// end at the first unescaped quote, others at the first space.
+func fieldValue(s string) (string, int, bool) {
+	if s == "" {
+		return "", 0, false
+	}
+
+	q := s[0]
+	if q != '"' && q != '\'' {
+		end := strings.IndexByte(s, ' ')
+		if end < 0 {
+			end = len(s)
+		}
+		if needsQuotes(s[:end]) {
+			return "", 0, false
+		}
+		return s[:end], end, true
+	}
+
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 == len(s) {
+				return "", 0, false
+			}
+			i++
+		case q:
+			if i+1 < len(s) && s[i+1] != ' ' {
+				return "", 0, false
+			}
+			return b.String(), i + 1, true
+		}
+		b.WriteByte(s[i])
+	}
+	return "", 0, false
+}
+
+// Scanner reads entries from a stream of text format log lines, such as a log
+// file. Lines that cannot be parsed, such as output of other programs or the
+// continuation lines of multiline messages, are skipped.
+type Scanner struct {
+	scanner *bufio.Scanner
+	entry   Entry
+}
+
+// NewScanner returns a Scanner reading from r
+func NewScanner(r io.Reader) *Scanner {
+	s := &Scanner{scanner: bufio.NewScanner(r)}
+	s.scanner.Buffer(nil, maxScanLine)
+	return s
+}
+
+// Scan advances to the next entry, returning false at the end of the input or on an error
+func (s *Scanner) Scan() bool {
+	for s.scanner.Scan() {
+		entry, err := Parse(s.scanner.Text())
+		if err == nil {
+			s.entry = entry
+			return true
+		}
+	}
+	return false
+}
+
+// Entry returns the entry read by the last call to Scan
+func (s *Scanner) Entry() Entry {
+	return s.entry
+}
+
+// Err returns the first read error, if any
+func (s *Scanner) Err() error {
+	return s.scanner.Err()
+}
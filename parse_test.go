@@ -0,0 +1,119 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// roundTripEntries covers every level, fields needing escapes and messages that contain separators
+var roundTripEntries = []Entry{
+	{ID: 1, Level: DebugLevel, Time: testTime, Message: "starting"},
+	{ID: 2, Level: InfoLevel, Time: testTime, Message: "request served", Fields: map[string]string{"status": "200", "path": "/a b"}},
+	{ID: 3, Level: WarnLevel, Time: testTime, Message: "slow, retrying", Fields: map[string]string{"quote": `say "hi"`}},
+	{ID: 4, Level: ErrorLevel, Time: testTime, Message: "failed", Fields: map[string]string{"path": `C:\logs\`, "error": `open "x": denied`}},
+	{ID: 5, Level: PanicLevel, Time: testTime, Message: "state: corrupt", Fields: map[string]string{"empty": ""}},
+	{ID: 6, Level: TraceLevel, Time: testTime, Message: "entered", Fields: map[string]string{"fn": "main"}},
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	for _, colored := range []bool{false, true} {
+		for _, want := range roundTripEntries {
+			line := string(TextFormatter{Colored: colored}.Format(want))
+			got, err := Parse(line)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", line, err)
+			}
+			if !entriesEqual(got, want) {
+				t.Errorf("Parse(%q) = %+v, want %+v", line, got, want)
+			}
+		}
+	}
+}
+
+func TestParseRoundTripLogger(t *testing.T) {
+	for _, style := range []QuoteStyle{QuoteDouble, QuoteSingle, QuoteIfNeeded} {
+		for _, sep := range []string{",", ""} {
+			logger, out := newTestLogger(WithQuoteStyle(style), WithFieldSeparator(sep), WithRecent(len(roundTripEntries)), WithLevel(DebugLevel))
+			for _, entry := range roundTripEntries {
+				logger.Log(entry.Level, entry.Message, fieldsOf(entry)...)
+			}
+
+			want := logger.Recent()
+			got := lines(out)
+			if len(got) != len(want) {
+				t.Fatalf("style %d, separator %q: wrote %d lines, want %d", style, sep, len(got), len(want))
+			}
+			for i, line := range got {
+				entry, err := Parse(line)
+				if err != nil {
+					t.Fatalf("Parse(%q): %v", line, err)
+				}
+				if !entriesEqual(entry, want[i]) {
+					t.Errorf("style %d, separator %q: Parse(%q) = %+v, want %+v", style, sep, line, entry, want[i])
+				}
+			}
+		}
+	}
+}
+
+func TestScanner(t *testing.T) {
+	var text strings.Builder
+	text.WriteString("not a log line\n")
+	for _, entry := range roundTripEntries {
+		text.Write(TextFormatter{}.Format(entry))
+		text.WriteString("  continuation\n")
+	}
+
+	scanner := NewScanner(strings.NewReader(text.String()))
+	var got []Entry
+	for scanner.Scan() {
+		got = append(got, scanner.Entry())
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(roundTripEntries) {
+		t.Fatalf("scanned %d entries, want %d", len(got), len(roundTripEntries))
+	}
+	for i := range got {
+		if !entriesEqual(got[i], roundTripEntries[i]) {
+			t.Errorf("entry %d = %+v, want %+v", i, got[i], roundTripEntries[i])
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, line := range []string{
+		"",
+		"hello",
+		"ID:x INFO 2024-10-10T10:24:44Z hi",
+		"ID:1 NOPE 2024-10-10T10:24:44Z hi",
+		"ID:1 INFO yesterday hi",
+	} {
+		if _, err := Parse(line); err == nil {
+			t.Errorf("Parse(%q) succeeded, want an error", line)
+		}
+	}
+}
+
+// fieldsOf returns the fields of entry as Field arguments
+func fieldsOf(entry Entry) []Field {
+	var fields []Field
+	for key, value := range entry.Fields {
+		fields = append(fields, String(key, value))
+	}
+	return fields
+}
+
+// entriesEqual reports whether a and b are equal, treating nil and empty fields alike
+func entriesEqual(a, b Entry) bool {
+	if len(a.Fields) == 0 && len(b.Fields) == 0 {
+		a.Fields, b.Fields = nil, nil
+	}
+	return a.ID == b.ID && a.Level == b.Level && a.Time.Equal(b.Time) && a.Message == b.Message && reflect.DeepEqual(a.Fields, b.Fields)
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"math"
+	"strconv"
+)
+
+// defaultPercentDigits is the number of decimals AddPercent writes by default
+const defaultPercentDigits = 1
+
+// WithPercentPrecision sets the number of decimals written by AddPercent, 1 by
+// default. Negative values are ignored.
+func WithPercentPrecision(digits int) Option {
+	return func(l *Logger) {
+		if digits >= 0 {
+			l.percentDigits = digits
+		}
+	}
+}
+
+// AddPercent adds a field holding ratio as a percentage, e.g. 0.425 as "42.5%",
+// and returns a new logger instance. Ratios outside 0..1 are written as they
+// are, such as "150.0%" for growth, and NaN or infinite ratios as "NaN", "+Inf"
+// or "-Inf".
+func (l *Logger) AddPercent(key string, ratio float64) *Logger {
+	key, ok := l.fieldKey(key)
+	if !ok {
+		return l
+	}
+	return l.withField(key, formatPercent(ratio, l.percentDigits))
+}
+
+// formatPercent formats ratio as a percentage with the given number of decimals
+func formatPercent(ratio float64, digits int) string {
+	if math.IsNaN(ratio) || math.IsInf(ratio, 0) {
+		return strconv.FormatFloat(ratio, 'f', -1, 64)
+	}
+	return strconv.FormatFloat(ratio*100, 'f', digits, 64) + "%"
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestFormatPercent(t *testing.T) {
+	tests := []struct {
+		ratio  float64
+		digits int
+		want   string
+	}{
+		{0, 1, "0.0%"},
+		{1, 1, "100.0%"},
+		{0.425, 1, "42.5%"},
+		{0.425, 0, "42%"},
+		{0.12345, 3, "12.345%"},
+		{1.5, 1, "150.0%"},
+		{-0.25, 1, "-25.0%"},
+		{math.NaN(), 1, "NaN"},
+		{math.Inf(1), 1, "+Inf"},
+		{math.Inf(-1), 1, "-Inf"},
+	}
+	for _, tt := range tests {
+		if got := formatPercent(tt.ratio, tt.digits); got != tt.want {
+			t.Errorf("formatPercent(%v, %d) = %q, want %q", tt.ratio, tt.digits, got, tt.want)
+		}
+	}
+}
+
+func TestAddPercent(t *testing.T) {
+	logger, out := newTestLogger()
+	logger.AddPercent("hit_rate", 0.425).Info("default precision")
+	New(WithOutput(out), WithPercentPrecision(2)).AddPercent("hit_rate", 0.425).Info("two decimals")
+	New(WithOutput(out), WithPercentPrecision(-1)).AddPercent("hit_rate", 1).Info("negative ignored")
+
+	got := lines(out)
+	for i, want := range []string{`hit_rate: "42.5%"`, `hit_rate: "42.50%"`, `hit_rate: "100.0%"`} {
+		if !strings.HasSuffix(got[i], want) {
+			t.Errorf("line %q, want suffix %q", got[i], want)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+// NewDevelopment creates a logger suited to working locally: colored text at
+// debug level with the caller's file and line, and multiline messages indented
+// under the first line. The given options are applied on top.
+func NewDevelopment(opts ...Option) *Logger {
+	preset := []Option{
+		WithLevel(DebugLevel),
+		WithFormat(FormatText),
+		WithColor(true),
+		WithCaller(CallerFile),
+		WithMultiline(MultilineIndent),
+	}
+	return New(append(preset, opts...)...)
+}
+
+// NewProduction creates a logger suited to services whose output is collected:
+// uncolored JSON at info level with UTC timestamps and no caller. The given
+// options are applied on top.
+func NewProduction(opts ...Option) *Logger {
+	preset := []Option{
+		WithLevel(InfoLevel),
+		WithFormat(FormatJSON),
+		WithColor(false),
+		WithUTC(true),
+		WithCaller(CallerOff),
+	}
+	return New(append(preset, opts...)...)
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestNewDevelopment(t *testing.T) {
+	var out bytes.Buffer
+	logger := NewDevelopment(WithOutput(StripANSI(&out)))
+	if !logger.colored || logger.format != FormatText || LogLevel(logger.level.Load()) != DebugLevel {
+		t.Errorf("colored, format, level = %v, %v, %v, want true, text, debug", logger.colored, logger.format, LogLevel(logger.level.Load()))
+	}
+	if logger.caller != CallerFile || logger.multiline != MultilineIndent {
+		t.Errorf("caller, multiline = %v, %v, want CallerFile, MultilineIndent", logger.caller, logger.multiline)
+	}
+
+	logger.Debug("hello")
+	if !strings.Contains(out.String(), `caller: "presets_test.go:`) {
+		t.Errorf("line %q has no caller", out.String())
+	}
+}
+
+func TestNewProduction(t *testing.T) {
+	logger := NewProduction(WithOutput(&bytes.Buffer{}))
+	if logger.colored || logger.format != FormatJSON || LogLevel(logger.level.Load()) != InfoLevel {
+		t.Errorf("colored, format, level = %v, %v, %v, want false, JSON, info", logger.colored, logger.format, LogLevel(logger.level.Load()))
+	}
+	if !logger.utc || logger.caller != CallerOff {
+		t.Errorf("utc, caller = %v, %v, want true, CallerOff", logger.utc, logger.caller)
+	}
+}
+
+func TestPresetOverrides(t *testing.T) {
+	logger := NewProduction(WithOutput(&bytes.Buffer{}), WithLevel(WarnLevel), WithFormat(FormatText))
+	if LogLevel(logger.level.Load()) != WarnLevel || logger.format != FormatText {
+		t.Errorf("options given to the preset were not applied on top")
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"strings"
+	"unicode"
+)
+
+// QuoteStyle controls how field values are quoted in the text format
+type QuoteStyle int
+
+const (
+	QuoteDouble   QuoteStyle = iota // key: "value", always
+	QuoteSingle                     // key: 'value', always
+	QuoteIfNeeded                   // key: value, quoting as "a b" only values that need it
+)
+
+// WithQuoteStyle sets how field values are quoted in the text format. Within
+// quotes, the quote character and \ are escaped by a backslash so Parse can
+// read the value back. Under QuoteIfNeeded, only values that are empty or
+// contain spaces, control characters or any of ",:='\ are quoted, with ".
+func WithQuoteStyle(style QuoteStyle) Option {
+	return func(l *Logger) {
+		l.enc.quote = style
+	}
+}
+
+// appendFieldValue appends value to dst quoted as configured, colored with
+// appendColoredValue when color is set
+func (e *encoding) appendFieldValue(dst []byte, level LogLevel, value, color string) []byte {
+	q := byte('"')
+	switch e.quote {
+	case QuoteSingle:
+		q = '\''
+	case QuoteIfNeeded:
+		if !needsQuotes(value) {
+			return e.appendColoredValue(dst, level, value, color)
+		}
+	}
+
+	dst = append(dst, q)
+	dst = e.appendColoredValue(dst, level, escapeQuoted(value, q), color)
+	return append(dst, q)
+}
+
+func needsQuotes(value string) bool {
+	if value == "" {
+		return true
+	}
+	return strings.IndexFunc(value, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsControl(r) || strings.ContainsRune(`",:='\`, r)
+	}) >= 0
+}
+
+// escapeQuoted escapes q and \ with a backslash
+func escapeQuoted(value string, q byte) string {
+	if strings.IndexByte(value, q) < 0 && strings.IndexByte(value, '\\') < 0 {
+		return value
+	}
+
+	var b strings.Builder
+	b.Grow(len(value) + 2)
+	for i := 0; i < len(value); i++ {
+		if value[i] == q || value[i] == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(value[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestQuoteStyles(t *testing.T) {
+	tests := []struct {
+		style QuoteStyle
+		value string
+		want  string
+	}{
+		{QuoteDouble, "200", `status: "200"`},
+		{QuoteDouble, `say "hi"`, `status: "say \"hi\""`},
+		{QuoteDouble, `C:\tmp`, `status: "C:\\tmp"`},
+		{QuoteSingle, "200", `status: '200'`},
+		{QuoteSingle, `it's`, `status: 'it\'s'`},
+		{QuoteIfNeeded, "200", `status: 200`},
+		{QuoteIfNeeded, "not found", `status: "not found"`},
+		{QuoteIfNeeded, "", `status: ""`},
+		{QuoteIfNeeded, `'x'`, `status: "'x'"`},
+		{QuoteIfNeeded, `a"b`, `status: "a\"b"`},
+	}
+	for _, tt := range tests {
+		logger, out := newTestLogger(WithQuoteStyle(tt.style))
+		logger.AddField("status", tt.value).Info("done")
+		if !strings.HasSuffix(out.String(), ", "+tt.want+"\n") {
+			t.Errorf("style %d, value %q: got %q, want suffix %q", tt.style, tt.value, out.String(), tt.want)
+		}
+	}
+}
+
+func TestParseQuoteStyles(t *testing.T) {
+	values := []string{"200", "not found", "", `say "hi"`, `it's`, `'x'`, `C:\tmp\`, `a: "b", c: "d"`}
+	for _, style := range []QuoteStyle{QuoteDouble, QuoteSingle, QuoteIfNeeded} {
+		for _, value := range values {
+			logger, out := newTestLogger(WithQuoteStyle(style))
+			logger.AddField("a", value).AddField("b", "x y").Info("done")
+			entry, err := Parse(out.String())
+			if err != nil {
+				t.Fatalf("style %d: Parse(%q): %v", style, out.String(), err)
+			}
+			if entry.Message != "done" || entry.Fields["a"] != value || entry.Fields["b"] != "x y" || len(entry.Fields) != 2 {
+				t.Errorf("style %d: Parse(%q) = %q, %q, want done, map[a:%q b:\"x y\"]", style, out.String(), entry.Message, entry.Fields, value)
+			}
+		}
+	}
+}
+
+func TestParseInvalidQuotes(t *testing.T) {
+	// A value with an unterminated quote is not read as a field
+	line := `ID:1 INFO 2024-10-10T10:24:44Z done, a: "open`
+	entry, err := Parse(line)
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", line, err)
+	}
+	if entry.Message != `done, a: "open` || entry.Fields != nil {
+		t.Errorf("Parse(%q) = %q, %q, want the whole text as the message", line, entry.Message, entry.Fields)
+	}
+}
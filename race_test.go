@@ -0,0 +1,10 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+//go:build race
+
+package trolog
+
+// raceEnabled is set when testing with -race, which makes sync.Pool drop items and so allocate
+const raceEnabled = true
@@ -0,0 +1,23 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+// Raw writes p as is, without ID, level, timestamp or fields, to the outputs an
+// entry at level would go to, e.g. to pass through the captured output of a
+// subprocess. It is left out of the terminal below the logger's level, and out
+// of sinks, which take entries, and of a JSON array log file, which it would
+// make invalid. p should end with a line ending. Raw content is not counted in
+// Stats and does not run the panic action.
+func (l *Logger) Raw(level LogLevel, p []byte) {
+	if len(p) == 0 || l.discards() {
+		return
+	}
+	st := l.loadState()
+	rec := record{level: level, line: p, plain: p, file: p, routes: st.routes, outputs: st.outputs, raw: true}
+
+	l.outMu.Lock()
+	l.write(&rec)
+	l.outMu.Unlock()
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRaw(t *testing.T) {
+	var out bytes.Buffer
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger := New(WithOutput(&out), WithLevel(WarnLevel), WithFile(path), WithLayout("{msg}"))
+	logger = logger.AddField("k", "v")
+
+	verbatim := []byte("  $ make\n\tcc -o app main.c: \"ok\" 100%\n")
+	logger.Raw(InfoLevel, []byte("below the level\n"))
+	logger.Raw(ErrorLevel, verbatim)
+	logger.Raw(ErrorLevel, nil)
+	_ = logger.Close()
+
+	if got := out.String(); got != string(verbatim) {
+		t.Errorf("terminal = %q, want exactly %q", got, verbatim)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The file gets every level, as it does for entries
+	if want := append([]byte("below the level\n"), verbatim...); !bytes.Equal(data, want) {
+		t.Errorf("file = %q, want %q", data, want)
+	}
+	if stats := logger.Stats(); stats[ErrorLevel] != 0 {
+		t.Errorf("Stats() = %v, want raw content uncounted", stats)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// Entry is a single log entry as seen by the logger
+type Entry struct {
+	ID      int32
+	Level   LogLevel
+	Time    time.Time
+	Message string
+	Fields  map[string]string
+}
+
+// ringBuffer keeps the last N entries, overwriting the oldest when full
+type ringBuffer struct {
+	mu      sync.Mutex
+	entries []Entry
+	next    int
+	full    bool
+}
+
+func newRingBuffer(size int) *ringBuffer {
+	return &ringBuffer{entries: make([]Entry, size)}
+}
+
+// add stores an entry, replacing the oldest one if the buffer is full
+func (r *ringBuffer) add(e Entry) {
+	r.mu.Lock()
+	r.entries[r.next] = e
+	r.next++
+	if r.next == len(r.entries) {
+		r.next = 0
+		r.full = true
+	}
+	r.mu.Unlock()
+}
+
+// snapshot returns a copy of the retained entries, oldest first
+func (r *ringBuffer) snapshot() []Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.full {
+		return append([]Entry(nil), r.entries[:r.next]...)
+	}
+	out := make([]Entry, 0, len(r.entries))
+	out = append(out, r.entries[r.next:]...)
+	out = append(out, r.entries[:r.next]...)
+	return out
+}
+
+// KeepRecent enables an in-memory buffer retaining the last size entries.
+// The buffer is shared with loggers derived afterwards; a size <= 0 disables it.
+func (l *Logger) KeepRecent(size int) {
+	var recent *ringBuffer
+	if size > 0 {
+		recent = newRingBuffer(size)
+	}
+	l.updateState(func(s *loggerState) { s.recent = recent })
+}
+
+// Recent returns the retained entries, oldest first, or nil if KeepRecent is not enabled
+func (l *Logger) Recent() []Entry {
+	recent := l.loadState().recent
+	if recent == nil {
+		return nil
+	}
+	return recent.snapshot()
+}
+
+// DumpRecent writes the retained entries, oldest first, to w in the format of
+// the logger without colors, e.g. to serve a /debug/logs endpoint or dump them
+// when recovering from a panic. It returns the number of bytes written, and
+// writes nothing if KeepRecent is not enabled.
+func (l *Logger) DumpRecent(w io.Writer) (int64, error) {
+	var out []byte
+	for _, entry := range l.Recent() {
+		out = append(out, l.encodeEntry(entry)...)
+	}
+	if len(out) == 0 {
+		return 0, nil
+	}
+	n, err := w.Write(out)
+	return int64(n), err
+}
+
+// encodeEntry encodes a retained entry as the plain line the logger wrote for it
+func (l *Logger) encodeEntry(entry Entry) []byte {
+	if l.formatter != nil {
+		return l.formatter.Format(entry)
+	}
+
+	timestamp := formatTimestamp(entry.Time, l.timeFormat)
+	if l.elapsed > 0 {
+		timestamp = formatElapsed(entry.Time.Sub(l.start), l.elapsed)
+	}
+	fields := fieldsFromMap(entry.Fields)
+	if l.format == FormatText {
+		return withLineEnding(buildLogMessage(&l.enc, entry.Level, timestamp, entry.Message, fields, nil, false, entry.ID), l.lineEnding)
+	}
+	return withLineEnding(l.appendStructured(nil, entry.Level, entry.Time, timestamp, entry.Message, fields, nil, entry.ID), l.lineEnding)
+}
+
+// mergeFields combines logger fields with per-call fields into a new map
+func mergeFields(fields []field, extraFields map[string]string) map[string]string {
+	merged := make(map[string]string, len(fields)+len(extraFields))
+	for _, f := range fields {
+		merged[f.key] = f.value
+	}
+	for k, v := range extraFields {
+		merged[k] = v
+	}
+	return merged
+}
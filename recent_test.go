@@ -0,0 +1,81 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestRecentOverflow(t *testing.T) {
+	logger, _ := newTestLogger(WithRecent(3))
+	for i := 1; i <= 5; i++ {
+		logger.Info(fmt.Sprintf("entry %d", i))
+	}
+
+	recent := logger.Recent()
+	if len(recent) != 3 {
+		t.Fatalf("Recent() returned %d entries, want 3", len(recent))
+	}
+	for i, entry := range recent {
+		if want := fmt.Sprintf("entry %d", i+3); entry.Message != want {
+			t.Errorf("Recent()[%d] = %q, want %q, oldest first", i, entry.Message, want)
+		}
+	}
+}
+
+func TestRecentPartial(t *testing.T) {
+	logger, _ := newTestLogger(WithRecent(3))
+	logger.Info("only")
+	if recent := logger.Recent(); len(recent) != 1 || recent[0].Message != "only" {
+		t.Errorf("Recent() = %v, want the single entry", recent)
+	}
+}
+
+func TestKeepRecent(t *testing.T) {
+	logger, _ := newTestLogger()
+	if recent := logger.Recent(); recent != nil {
+		t.Errorf("Recent() = %v without KeepRecent, want nil", recent)
+	}
+
+	logger.KeepRecent(2)
+	derived := logger.AddField("k", "v")
+	derived.Info("derived")
+	if recent := logger.Recent(); len(recent) != 1 || recent[0].Fields["k"] != "v" {
+		t.Errorf("Recent() = %v, want the entry of the derived logger", recent)
+	}
+
+	logger.KeepRecent(0)
+	if recent := logger.Recent(); recent != nil {
+		t.Errorf("Recent() = %v after disabling, want nil", recent)
+	}
+}
+
+func TestDumpRecent(t *testing.T) {
+	for _, format := range []Format{FormatText, FormatJSON} {
+		logger, out := newTestLogger(WithRecent(2), WithFormat(format), WithColor(true))
+		logger.Info("dropped from the buffer")
+		out.Reset()
+		logger.AddField("k", "v").Warn("second")
+		logger.Error("third")
+
+		var dump bytes.Buffer
+		n, err := logger.DumpRecent(&dump)
+		if err != nil || n != int64(dump.Len()) {
+			t.Errorf("format %v: DumpRecent = %d, %v for %d bytes", format, n, err, dump.Len())
+		}
+		if dump.String() != out.String() {
+			t.Errorf("format %v: dump %q, want the lines as written %q", format, dump.String(), out.String())
+		}
+	}
+
+	var dump bytes.Buffer
+	logger, _ := newTestLogger()
+	logger.Info("not retained")
+	if n, err := logger.DumpRecent(&dump); n != 0 || err != nil || dump.Len() != 0 {
+		t.Errorf("DumpRecent without WithRecent = %d, %v, %q", n, err, dump.String())
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"runtime/debug"
+	"time"
+)
+
+// Recover logs a panic in progress at Panic level with the stack trace in a
+// "stack" field. It must be deferred directly, typically at the top of a goroutine:
+//
+//	defer logger.Recover()
+//
+// The panic is swallowed unless the logger was created with WithRepanic(true);
+// the panic action set with WithPanicAction does not apply.
+func (l *Logger) Recover() {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	message := "panic: "
+	if err, ok := r.(error); ok {
+		message += err.Error()
+	} else {
+		message += valueToString(r)
+	}
+	l.emit(time.Time{}, PanicLevel, message, map[string]string{"stack": string(debug.Stack())}, nil)
+
+	if l.repanic {
+		panic(r)
+	}
+}
+
+// WithRepanic makes Recover panic again with the original value after logging it
+func WithRepanic(repanic bool) Option {
+	return func(l *Logger) {
+		l.repanic = repanic
+	}
+}
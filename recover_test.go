@@ -0,0 +1,63 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRecover(t *testing.T) {
+	logger, _ := newTestLogger(WithRecent(2), WithPanicAction(ActionPanic()))
+	func() {
+		defer logger.Recover()
+		panic("out of range")
+	}()
+	func() {
+		defer logger.Recover()
+		panic(errors.New("nil map"))
+	}()
+
+	recent := logger.Recent()
+	if len(recent) != 2 {
+		t.Fatalf("Recent() = %v, want both panics logged", recent)
+	}
+	for i, want := range []string{"panic: out of range", "panic: nil map"} {
+		entry := recent[i]
+		if entry.Level != PanicLevel || entry.Message != want {
+			t.Errorf("entry %d = %v %q, want PANI %q", i, entry.Level, entry.Message, want)
+		}
+		if stack := entry.Fields["stack"]; !strings.Contains(stack, "TestRecover") {
+			t.Errorf("stack field %q does not show the panicking function", stack)
+		}
+	}
+}
+
+func TestRecoverNoPanic(t *testing.T) {
+	logger, out := newTestLogger()
+	func() {
+		defer logger.Recover()
+	}()
+	if out.Len() != 0 {
+		t.Errorf("output %q without a panic", out.String())
+	}
+}
+
+func TestRecoverRepanic(t *testing.T) {
+	logger, out := newTestLogger(WithRepanic(true))
+	defer func() {
+		if r := recover(); r != "again" {
+			t.Errorf("recovered %v, want the original value", r)
+		}
+		if !strings.Contains(out.String(), "panic: again") {
+			t.Errorf("output %q, want the panic logged before panicking again", out.String())
+		}
+	}()
+	func() {
+		defer logger.Recover()
+		panic("again")
+	}()
+}
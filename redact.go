@@ -0,0 +1,81 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import "regexp"
+
+// Patterns for common sensitive values, for use with WithRedactPattern
+const (
+	CardNumberPattern = `\b(?:\d[ -]?){12,18}\d\b`
+	EmailPattern      = `[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`
+)
+
+// defaultMask replaces redacted values when no mask is given
+const defaultMask = "[REDACTED]"
+
+// redactor replaces matches of a pattern with a mask
+type redactor struct {
+	pattern *regexp.Regexp
+	mask    string
+}
+
+// WithRedactPattern replaces every match of pattern in messages and field
+// values with mask ("[REDACTED]" when empty) before entries are written
+// anywhere, guarding against leaking e.g. card numbers whatever the field is
+// called. It may be given several times; patterns apply in order.
+//
+//	trolog.WithRedactPattern(regexp.MustCompile(trolog.CardNumberPattern), "****")
+func WithRedactPattern(pattern *regexp.Regexp, mask string) Option {
+	return func(l *Logger) {
+		if mask == "" {
+			mask = defaultMask
+		}
+		l.redactors = append(l.redactors, redactor{pattern: pattern, mask: mask})
+	}
+}
+
+// redact applies all redactors to s
+func redact(redactors []redactor, s string) string {
+	for _, r := range redactors {
+		s = r.pattern.ReplaceAllLiteralString(s, r.mask)
+	}
+	return s
+}
+
+// redactFields returns fields with all redactors applied to the values, or
+// fields itself if nothing matched
+func redactFields(redactors []redactor, fields map[string]string) map[string]string {
+	var out map[string]string
+	for k, v := range fields {
+		if r := redact(redactors, v); r != v {
+			if out == nil {
+				out = copyFields(fields, 0)
+			}
+			out[k] = r
+		}
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
+
+// redactFieldList returns fields with all redactors applied to the values, or
+// fields itself if nothing matched
+func redactFieldList(redactors []redactor, fields []field) []field {
+	var out []field
+	for i, f := range fields {
+		if r := redact(redactors, f.value); r != f.value {
+			if out == nil {
+				out = append([]field(nil), fields...)
+			}
+			out[i].value = r
+		}
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
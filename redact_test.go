@@ -0,0 +1,37 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestRedactCardNumber(t *testing.T) {
+	logger, out := newTestLogger(
+		WithRedactPattern(regexp.MustCompile(CardNumberPattern), "****"),
+		WithRedactPattern(regexp.MustCompile(EmailPattern), ""),
+		WithRecent(4),
+	)
+	logger.AddField("note", "card 4111-1111-1111-1111").
+		Info("charged 4111 1111 1111 1111 for ana@example.com", String("raw", "4111111111111111"))
+	logger.Info("order 12345 shipped")
+
+	got := lines(out)
+	want := `charged **** for [REDACTED], note: "card ****" raw: "****"`
+	if !strings.HasSuffix(got[0], want) {
+		t.Errorf("line %q, want suffix %q", got[0], want)
+	}
+	if strings.Contains(out.String(), "4111") || strings.Contains(out.String(), "ana@") {
+		t.Errorf("sensitive value leaked in %q", out.String())
+	}
+	if !strings.HasSuffix(got[1], "order 12345 shipped") {
+		t.Errorf("short number was masked: %q", got[1])
+	}
+	if recent := logger.Recent(); strings.Contains(recent[0].Message, "4111") {
+		t.Errorf("Recent kept the unmasked message %q", recent[0].Message)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// levelReloader polls a file holding a level name and applies it to a logger
+type levelReloader struct {
+	path     string
+	interval time.Duration
+	l        *Logger
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// WithLevelReload reads the level from the file at path, holding a name such as
+// "debug", and checks it again every interval (one second when not positive), so
+// verbosity can be changed in production without a restart. The level is shared
+// with derived loggers. A missing file or unknown name keeps the current level.
+// The file is no longer watched after Close or Shutdown.
+func WithLevelReload(path string, interval time.Duration) Option {
+	return func(l *Logger) {
+		if interval <= 0 {
+			interval = time.Second
+		}
+		r := &levelReloader{
+			path:     path,
+			interval: interval,
+			l:        l,
+			stop:     make(chan struct{}),
+			done:     make(chan struct{}),
+		}
+		r.reload()
+		l.reloader = r
+		go r.run()
+	}
+}
+
+// run reloads the level on every tick until stopped
+func (r *levelReloader) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			r.reload()
+		case <-r.stop:
+			return
+		}
+	}
+}
+
+// reload applies the level named in the file, if it can be read and is known
+func (r *levelReloader) reload() {
+	data, err := os.ReadFile(r.path)
+	if err != nil {
+		return
+	}
+	if level, ok := lookupLogLevel(strings.TrimSpace(string(data))); ok {
+		r.l.setLevel(level)
+	}
+}
+
+// Close stops watching the file
+func (r *levelReloader) Close() error {
+	r.once.Do(func() { close(r.stop) })
+	<-r.done
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// waitLevel waits until l's level is want
+func waitLevel(t *testing.T, l *Logger, want LogLevel) {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); l.minLevel() != want; time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("level is %v, want %v", l.minLevel(), want)
+		}
+	}
+}
+
+func TestWithLevelReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "level")
+	write := func(level string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(level), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("warn\n")
+	logger, out := newTestLogger(WithLevelReload(path, 5*time.Millisecond))
+	derived := logger.AddField("k", "v")
+	if got := logger.minLevel(); got != WarnLevel {
+		t.Fatalf("level = %v, want the file's level as soon as the logger is created", got)
+	}
+
+	write("debug")
+	waitLevel(t, derived, DebugLevel)
+	derived.Debug("now visible")
+	if len(lines(out)) != 1 {
+		t.Errorf("got lines %q, want the debug entry", lines(out))
+	}
+
+	// Unknown names and a missing file keep the current level
+	write("loud")
+	time.Sleep(30 * time.Millisecond)
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if got := logger.minLevel(); got != DebugLevel {
+		t.Errorf("level = %v after an unknown name and removing the file, want DEBU", got)
+	}
+
+	// Closing the last logger stops watching
+	_ = derived.Close()
+	_ = logger.Close()
+	write("error")
+	time.Sleep(30 * time.Millisecond)
+	if got := logger.minLevel(); got != DebugLevel {
+		t.Errorf("level = %v after Close, want DEBU", got)
+	}
+}
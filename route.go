@@ -0,0 +1,77 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import "io"
+
+// route sends terminal output from minLevel upwards to its own writer
+type route struct {
+	minLevel LogLevel
+	output   io.Writer
+}
+
+// RouteLevel sends terminal output at minLevel and above to w instead of the
+// regular output, splitting the stream by level. An entry goes to the routes
+// with the highest minLevel at or below its level, or to the regular output
+// if there is none, so RouteLevel(ErrorLevel, os.Stderr) sends errors to
+// stderr and everything else to the output as before. Levels are ordered by
+// severity with Trace below Debug, so only a TraceLevel route takes Trace
+// entries. Loggers derived afterwards share the routes. A nil w discards the
+// routed entries.
+func (l *Logger) RouteLevel(minLevel LogLevel, w io.Writer) {
+	if w == nil {
+		w = io.Discard
+	}
+	r := route{minLevel: minLevel, output: prepareColorOutput(w)}
+	l.updateState(func(s *loggerState) {
+		routes := make([]route, len(s.routes), len(s.routes)+1)
+		copy(routes, s.routes)
+		s.routes = append(routes, r)
+	})
+}
+
+// WithRoute is the option form of RouteLevel
+func WithRoute(minLevel LogLevel, w io.Writer) Option {
+	return func(l *Logger) {
+		l.RouteLevel(minLevel, w)
+	}
+}
+
+// AddOutput also writes entries at minLevel and above to w, alongside the
+// regular output, routes and files, e.g. AddOutput(remote, WarnLevel) to only
+// forward warnings and errors. Like per-level files, each output filters by its
+// own minLevel rather than the logger's level, with Trace below Debug, and gets
+// uncolored lines in the format of the log file. Loggers derived afterwards share the outputs.
+func (l *Logger) AddOutput(w io.Writer, minLevel LogLevel) {
+	if w == nil {
+		return
+	}
+	o := route{minLevel: minLevel, output: w}
+	l.updateState(func(s *loggerState) {
+		outputs := make([]route, len(s.outputs), len(s.outputs)+1)
+		copy(outputs, s.outputs)
+		s.outputs = append(outputs, o)
+	})
+}
+
+// WithAddedOutput is the option form of AddOutput
+func WithAddedOutput(w io.Writer, minLevel LogLevel) Option {
+	return func(l *Logger) {
+		l.AddOutput(w, minLevel)
+	}
+}
+
+// routeFor returns the most severe route minimum that level reaches, and false
+// if no route applies
+func routeFor(routes []route, level LogLevel) (LogLevel, bool) {
+	var best LogLevel
+	found := false
+	for _, r := range routes {
+		if level.atLeast(r.minLevel) && (!found || r.minLevel.atLeast(best)) {
+			best, found = r.minLevel, true
+		}
+	}
+	return best, found
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRouteLevel(t *testing.T) {
+	var warnings, errs bytes.Buffer
+	logger, out := newTestLogger(WithRoute(WarnLevel, StripANSI(&warnings)))
+	logger.RouteLevel(ErrorLevel, StripANSI(&errs))
+	derived := logger.AddField("k", "v")
+
+	logger.Info("info")
+	logger.Trace("trace")
+	derived.Warn("warn")
+	logger.Error("error")
+	derived.Panic("panic")
+
+	check := func(name string, buf *bytes.Buffer, want ...string) {
+		t.Helper()
+		got := lines(buf)
+		if len(got) != len(want) {
+			t.Fatalf("%s got lines %q, want %q", name, got, want)
+		}
+		for i := range want {
+			if !strings.Contains(got[i], " "+want[i]) {
+				t.Errorf("%s line %q, want message %q", name, got[i], want[i])
+			}
+		}
+	}
+	check("output", out, "info", "trace")
+	check("warn route", &warnings, "warn")
+	check("error route", &errs, "error", "panic")
+}
+
+func TestRouteLevelTrace(t *testing.T) {
+	var traces, errs bytes.Buffer
+	logger, out := newTestLogger(WithRoute(TraceLevel, StripANSI(&traces)), WithRoute(ErrorLevel, StripANSI(&errs)))
+	logger.Trace("t")
+	logger.Info("i")
+	logger.Error("e")
+
+	// Every level reaches a TraceLevel route, the more severe route still wins
+	if got := lines(&traces); len(got) != 2 || !strings.Contains(got[0], " t") || !strings.HasSuffix(got[1], " i") {
+		t.Errorf("trace route got %q, want the Trace and Info entries", got)
+	}
+	if got := lines(&errs); len(got) != 1 || !strings.HasSuffix(got[0], " e") {
+		t.Errorf("error route got %q, want only the Error entry", got)
+	}
+	if got := lines(out); len(got) != 0 {
+		t.Errorf("output got %q, want everything routed", got)
+	}
+}
+
+func TestRouteLevelNil(t *testing.T) {
+	logger, out := newTestLogger(WithRoute(ErrorLevel, nil))
+	logger.Error("discarded")
+	logger.Info("kept")
+	if got := lines(out); len(got) != 1 || !strings.HasSuffix(got[0], "kept") {
+		t.Errorf("got lines %q, want only the unrouted entry", got)
+	}
+}
+
+func TestAddOutputThresholds(t *testing.T) {
+	var all, remote bytes.Buffer
+	logger, out := newTestLogger(WithLevel(InfoLevel), WithAddedOutput(&all, DebugLevel), WithFormat(FormatJSON))
+	logger.AddOutput(&remote, WarnLevel)
+	logger.AddOutput(nil, DebugLevel)
+
+	logger.Debug("debug")
+	logger.Trace("trace")
+	logger.AddField("k", "v").Info("info")
+	logger.Warn("warn")
+	logger.Error("error")
+
+	msgs := func(text string) string {
+		var got []string
+		for _, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+			if i := strings.Index(line, `"msg":"`); i >= 0 {
+				got = append(got, strings.SplitN(line[i+7:], `"`, 2)[0])
+			}
+		}
+		return strings.Join(got, " ")
+	}
+	for name, tt := range map[string]struct{ got, want string }{
+		"terminal": {out.String(), "trace info warn error"},
+		"all":      {all.String(), "debug info warn error"},
+		"remote":   {remote.String(), "warn error"},
+	} {
+		if got := msgs(tt.got); got != tt.want {
+			t.Errorf("%s got %q, want %q", name, got, tt.want)
+		}
+	}
+	if strings.Contains(all.String(), "\033[") || !strings.Contains(all.String(), `"k":"v"`) {
+		t.Errorf("added output %q, want uncolored lines with fields", all.String())
+	}
+}
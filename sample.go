@@ -0,0 +1,38 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import "sync/atomic"
+
+// sampler keeps one in every rate entries per level, shared between a logger and its derived loggers
+type sampler struct {
+	rates  [TraceLevel + 1]uint64
+	counts [TraceLevel + 1]uint64
+}
+
+// WithSampling writes only one in every n entries of the levels in rates, e.g.
+// map[LogLevel]int{DebugLevel: 100} keeps the 1st, 101st, 201st... debug entry
+// while other levels are written in full. Sampled out entries are dropped from
+// every output before they are assigned a log ID.
+func WithSampling(rates map[LogLevel]int) Option {
+	return func(l *Logger) {
+		s := &sampler{}
+		for level, n := range rates {
+			if level >= DebugLevel && level <= TraceLevel && n > 1 {
+				s.rates[level] = uint64(n)
+			}
+		}
+		l.sampler = s
+	}
+}
+
+// keep reports whether the next entry at level should be written
+func (s *sampler) keep(level LogLevel) bool {
+	if level < DebugLevel || level > TraceLevel || s.rates[level] == 0 {
+		return true
+	}
+	n := atomic.AddUint64(&s.counts[level], 1)
+	return (n-1)%s.rates[level] == 0
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestWithSampling(t *testing.T) {
+	logger, out := newTestLogger(WithLevel(DebugLevel), WithSampling(map[LogLevel]int{DebugLevel: 10, InfoLevel: 1, WarnLevel: 0}))
+	for i := 0; i < 1000; i++ {
+		logger.Debug("debug")
+	}
+	for i := 0; i < 5; i++ {
+		logger.Info("info")
+		logger.Warn("warn")
+	}
+
+	var debug, info, warn int
+	for _, line := range lines(out) {
+		switch {
+		case strings.HasSuffix(line, " debug"):
+			debug++
+		case strings.HasSuffix(line, " info"):
+			info++
+		case strings.HasSuffix(line, " warn"):
+			warn++
+		}
+	}
+	if debug != 100 || info != 5 || warn != 5 {
+		t.Errorf("wrote %d debug, %d info and %d warn lines, want 100 of 1000 and every other entry", debug, info, warn)
+	}
+	if stats := logger.Stats(); stats[DebugLevel] != 100 {
+		t.Errorf("Stats()[DebugLevel] = %d, want only the kept entries counted", stats[DebugLevel])
+	}
+}
+
+func TestWithSamplingConcurrent(t *testing.T) {
+	logger, out := newTestLogger(WithSampling(map[LogLevel]int{InfoLevel: 100}))
+	derived := logger.AddField("k", "v")
+
+	var wg sync.WaitGroup
+	for g := 0; g < 10; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			l := logger
+			if g%2 == 1 {
+				l = derived
+			}
+			for i := 0; i < 100; i++ {
+				l.Info("m")
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	// Derived loggers share the counters, so exactly 1 in 100 of all entries is kept
+	if got := len(lines(out)); got != 10 {
+		t.Errorf("wrote %d lines, want 10 of 1000", got)
+	}
+}
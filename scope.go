@@ -0,0 +1,61 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+// pushedField remembers what PushField replaced so PopField can restore it
+type pushedField struct {
+	key     string
+	prev    string
+	existed bool
+}
+
+// PushField adds a field to this logger in place until the matching PopField,
+// as an alternative to AddField for imperative code:
+//
+//	logger.PushField("step", "migrate")
+//	defer logger.PopField()
+//
+// The field is visible to every goroutine using this logger, so scopes pushed
+// from different goroutines interleave; use AddField for per-goroutine fields.
+// Loggers derived while the field is pushed keep it.
+func (l *Logger) PushField(key string, value interface{}) {
+	key, ok := l.fieldKey(key)
+	if !ok {
+		return
+	}
+	str := valueToString(value)
+	l.updateState(func(s *loggerState) {
+		prev, existed := lookupField(s.fields, key)
+		l.fieldStack = append(l.fieldStack, pushedField{key: key, prev: prev, existed: existed})
+		s.fields = setField(s.fields, key, str)
+	})
+}
+
+// PopField removes the most recently pushed field, restoring any value it replaced.
+// It does nothing if no field is pushed.
+func (l *Logger) PopField() {
+	l.updateState(func(s *loggerState) {
+		if len(l.fieldStack) == 0 {
+			return
+		}
+		pushed := l.fieldStack[len(l.fieldStack)-1]
+		l.fieldStack = l.fieldStack[:len(l.fieldStack)-1]
+
+		if pushed.existed {
+			s.fields = setField(s.fields, pushed.key, pushed.prev)
+		} else {
+			s.fields = removeField(s.fields, pushed.key)
+		}
+	})
+}
+
+// copyFields returns a copy of fields with room for extra more entries
+func copyFields(fields map[string]string, extra int) map[string]string {
+	out := make(map[string]string, len(fields)+extra)
+	for k, v := range fields {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPushPopField(t *testing.T) {
+	logger, out := newTestLogger()
+	logger = logger.AddField("step", "init")
+
+	logger.PushField("step", "migrate")
+	logger.PushField("table", "users")
+	logger.Info("nested")
+	derived := logger.AddField("k", "v")
+	logger.PopField()
+	logger.Info("outer")
+	logger.PopField()
+	logger.Info("restored")
+	logger.PopField() // Nothing pushed, no effect
+	logger.Info("still restored")
+	derived.Info("derived")
+
+	want := []string{
+		`nested, step: "migrate" table: "users"`,
+		`outer, step: "migrate"`,
+		`restored, step: "init"`,
+		`still restored, step: "init"`,
+		`derived, step: "migrate" table: "users" k: "v"`,
+	}
+	got := lines(out)
+	if len(got) != len(want) {
+		t.Fatalf("got lines %q, want %d", got, len(want))
+	}
+	for i := range want {
+		if !strings.HasSuffix(got[i], want[i]) {
+			t.Errorf("line %q, want suffix %q", got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+// SyslogSeverity maps level to its syslog severity, 2 (critical) for PanicLevel
+// down to 7 (debug) for DebugLevel and TraceLevel, as used by FormatGELF
+func SyslogSeverity(level LogLevel) int {
+	return syslogLevel(level)
+}
+
+// WithSeverity adds a numeric severity, mapped from the level by mapping, to
+// JSON entries under key, for ingestion systems that filter on a number rather
+// than a label, e.g. WithSeverity("severity", SyslogSeverity, false). A nil
+// mapping writes the LogLevel value. With replace, the severity takes the place
+// of the "level" label. In FormatGELF, mapping replaces the syslog severity of
+// the "level" field and key is ignored.
+func WithSeverity(key string, mapping func(LogLevel) int, replace bool) Option {
+	return func(l *Logger) {
+		if mapping == nil {
+			mapping = func(level LogLevel) int { return int(level) }
+		}
+		if key == "" {
+			key = "severity"
+		}
+		l.enc.severityKey = key
+		l.enc.severity = mapping
+		l.enc.severityOnly = replace
+	}
+}
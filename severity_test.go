@@ -0,0 +1,55 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestWithSeverity(t *testing.T) {
+	syslog := map[LogLevel]float64{DebugLevel: 7, InfoLevel: 6, WarnLevel: 4, ErrorLevel: 3, PanicLevel: 2, TraceLevel: 7}
+	for level := DebugLevel; level <= TraceLevel; level++ {
+		tests := []struct {
+			opt       Option
+			key       string
+			want      float64
+			wantLabel bool
+		}{
+			{WithSeverity("severity", SyslogSeverity, false), "severity", syslog[level], true},
+			{WithSeverity("", nil, false), "severity", float64(level), true},
+			{WithSeverity("level_num", func(l LogLevel) int { return 10 * int(l) }, false), "level_num", float64(10 * level), true},
+			{WithSeverity("level", SyslogSeverity, true), "level", syslog[level], false},
+		}
+		for _, tt := range tests {
+			logger, out := newTestLogger(tt.opt, WithFormat(FormatJSON), WithLevel(DebugLevel), WithTraceCaller(CallerOff))
+			logger.Log(level, "m")
+
+			var entry map[string]interface{}
+			if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+				t.Fatalf("line %q is not JSON: %v", out.String(), err)
+			}
+			if entry[tt.key] != tt.want {
+				t.Errorf("%v: %s = %v (%T), want %v in %s", level, tt.key, entry[tt.key], entry[tt.key], tt.want, out.String())
+			}
+			if tt.wantLabel && entry["level"] != level.String() {
+				t.Errorf("%v: level label = %v in %s", level, entry["level"], out.String())
+			}
+		}
+	}
+}
+
+func TestWithSeverityGELF(t *testing.T) {
+	logger, out := newTestLogger(WithFormat(FormatGELF), WithSeverity("ignored", func(LogLevel) int { return 1 }, false))
+	logger.Error("m")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry["level"] != float64(1) || entry["ignored"] != nil {
+		t.Errorf("entry = %v, want the mapped GELF level and no extra key", entry)
+	}
+}
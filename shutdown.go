@@ -0,0 +1,66 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"context"
+	"errors"
+)
+
+// Shutdown flushes and closes everything the logger writes to, for use when a
+// server stops. Entries queued for network and webhook sinks are sent and log
+// files are synced to disk before being closed. If ctx is done first, Shutdown
+// returns its error while the sinks keep flushing in the background. Like Close,
+// Shutdown only closes the outputs when no other logger sharing them is open,
+// and otherwise flushes buffered file output.
+func (l *Logger) Shutdown(ctx context.Context) error {
+	if l.closed.Swap(true) {
+		return nil
+	}
+	if l.refs.Add(-1) > 0 {
+		return l.Flush()
+	}
+
+	var errs []error
+	if l.dropReporter != nil {
+		errs = append(errs, l.dropReporter.Close())
+	}
+
+	sinksDone := make(chan error, 1)
+	go func() {
+		var errs []error
+		for _, s := range l.sinks {
+			errs = append(errs, s.Close())
+		}
+		sinksDone <- errors.Join(errs...)
+	}()
+
+	if l.reloader != nil {
+		errs = append(errs, l.reloader.Close())
+	}
+	if l.file != nil {
+		if l.array != nil {
+			errs = append(errs, l.closeArray())
+		}
+		if l.fileBuf != nil {
+			errs = append(errs, l.fileBuf.Close())
+		}
+		if l.gzFile != nil {
+			errs = append(errs, l.gzFile.Close())
+		}
+		errs = append(errs, l.file.Sync(), l.file.Close())
+	}
+	for _, f := range l.levelFiles {
+		errs = append(errs, f.sync(), f.Close())
+	}
+
+	select {
+	case err := <-sinksDone:
+		errs = append(errs, err)
+	case <-ctx.Done():
+		errs = append(errs, ctx.Err())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// blockingSink is a sink whose Close waits until release is closed
+type blockingSink struct {
+	release chan struct{}
+}
+
+func (s *blockingSink) write(entry Entry, line []byte) {}
+
+func (s *blockingSink) Close() error {
+	<-s.release
+	return nil
+}
+
+func TestShutdownFlushesPending(t *testing.T) {
+	c := newCollector(t)
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger := New(WithOutput(io.Discard), WithFile(path), WithFileBuffer(4096, time.Hour), WithNetwork("tcp", c.addr()))
+	for i := 0; i < 50; i++ {
+		logger.Info("pending")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := logger.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(data), "pending"); n != 50 {
+		t.Errorf("file has %d of 50 buffered entries after Shutdown", n)
+	}
+	if n := strings.Count(string(c.received(t, 1)), "pending"); n != 50 {
+		t.Errorf("collector received %d of 50 queued entries", n)
+	}
+}
+
+func TestShutdownDeadline(t *testing.T) {
+	s := &blockingSink{release: make(chan struct{})}
+	defer close(s.release)
+	logger := New(WithOutput(io.Discard), withSink(func() sink { return s }))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := logger.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Shutdown = %v, want the context's error", err)
+	}
+}
+
+func TestShutdownDerived(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger := New(WithOutput(io.Discard), WithFile(path), WithFileBuffer(4096, time.Hour))
+	derived := logger.AddField("k", "v")
+	derived.Info("flushed")
+	if err := derived.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// The parent keeps writing after a derived logger shuts down
+	logger.Info("still open")
+	if err := logger.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "flushed") || !strings.Contains(string(data), "still open") {
+		t.Errorf("file = %q", data)
+	}
+}
@@ -0,0 +1,22 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+// sink is an additional destination that receives entries at or above the
+// logger's level alongside the terminal output. line holds the plain encoded
+// entry and is only valid for the duration of the call.
+type sink interface {
+	write(entry Entry, line []byte)
+	Close() error
+}
+
+// withSink returns an option that registers the sink returned by newSink with
+// the logger. newSink runs each time the option is applied, so background work
+// of the sink only starts for loggers using it and is never shared between them.
+func withSink(newSink func() sink) Option {
+	return func(l *Logger) {
+		l.sinks = append(l.sinks, newSink())
+	}
+}
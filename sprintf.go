@@ -0,0 +1,69 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import "fmt"
+
+// WithFmtFallback formats messages of Infof and the like with fmt.Sprintf
+// whenever the built-in formatting cannot handle them: verbs other than %s, %v,
+// %d, %b, %o and %f, flags, width and precision such as %+v or %5.2f, a %d, %b
+// or %o argument that is not an integer, a %f argument that is not a float64,
+// or a number of arguments that does not match the verbs. Simple format strings
+// keep the built-in fast path, which writes %s and %v values as fields are
+// written and %f with two decimals.
+func WithFmtFallback(enabled bool) Option {
+	return func(l *Logger) {
+		l.fmtFallback = enabled
+	}
+}
+
+// sprintf formats a message with formatMessage, or fmt.Sprintf when enabled and
+// needed. As format and args are passed on to fmt.Sprintf, go vet treats Infof
+// and every other f-method as a printf wrapper and checks their calls like those
+// of fmt.Printf, so only fmt verbs with arguments of matching types pass vet.
+// The built-in formatting must not accept verbs fmt does not know.
+func (l *Logger) sprintf(format string, args ...interface{}) string {
+	if l.fmtFallback && needsFmt(format, args) {
+		return fmt.Sprintf(format, args...)
+	}
+	return formatMessage(format, args...)
+}
+
+// needsFmt reports whether format with args is beyond what formatMessage supports
+func needsFmt(format string, args []interface{}) bool {
+	argIndex := 0
+	for i := 0; i < len(format)-1; i++ {
+		if format[i] != '%' {
+			continue
+		}
+		switch format[i+1] {
+		case 's', 'v':
+		case 'd', 'b', 'o':
+			if argIndex < len(args) && !isInteger(args[argIndex]) {
+				return true
+			}
+		case 'f':
+			if argIndex < len(args) {
+				if _, ok := args[argIndex].(float64); !ok {
+					return true
+				}
+			}
+		default:
+			return true
+		}
+		argIndex++
+		i++ // Skip the format specifier
+	}
+	return argIndex != len(args)
+}
+
+// isInteger reports whether value is of one of the integer types
+func isInteger(value interface{}) bool {
+	switch value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr:
+		return true
+	}
+	return false
+}
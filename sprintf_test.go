@@ -0,0 +1,56 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"testing"
+)
+
+type point struct {
+	X, Y int
+}
+
+func TestFmtFallbackMatchesSprintf(t *testing.T) {
+	l := New(WithOutput(io.Discard), WithFmtFallback(true))
+	tests := []struct {
+		format string
+		args   []interface{}
+	}{
+		// Handed to fmt.Sprintf
+		{"%+v", []interface{}{point{1, 2}}},
+		{"%#v", []interface{}{point{1, 2}}},
+		{"%#v", []interface{}{map[string]int{"a": 1}}},
+		{"%5.2f|%-6d|%6s|", []interface{}{math.Pi, 42, "ab"}},
+		{"%08.3f", []interface{}{-1.5}},
+		{"%x %q %t", []interface{}{255, "quoted", true}},
+		{"%5d of %-5d", []interface{}{3, 10}},
+		{"%f", []interface{}{float32(0.5)}},
+		{"%b", []interface{}{"not an integer"}},
+		{"%d %d", []interface{}{1}},
+		// Built-in fast path
+		{"%s=%d", []interface{}{"n", -7}},
+		{"%v", []interface{}{"plain"}},
+		{"%b %o", []interface{}{5, 8}},
+		{"%d", []interface{}{uint64(math.MaxUint64)}},
+		{"100%% done", nil},
+	}
+	for _, tt := range tests {
+		want := fmt.Sprintf(tt.format, tt.args...)
+		if got := l.sprintf(tt.format, tt.args...); got != want {
+			t.Errorf("sprintf(%q, %v) = %q, fmt.Sprintf gives %q", tt.format, tt.args, got, want)
+		}
+	}
+}
+
+func TestFmtFallbackDisabled(t *testing.T) {
+	l := New(WithOutput(io.Discard))
+	format := "%+v"
+	if got := l.sprintf(format, point{1, 2}); got == fmt.Sprintf(format, point{1, 2}) {
+		t.Errorf("sprintf without fallback used fmt: %q", got)
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+// loggerState holds the settings of a logger that can change after it is created.
+// It is never modified, changes store a new copy, so logging reads it without locking.
+// Maps and slices in it may be shared with derived loggers and are copied on write.
+type loggerState struct {
+	fields      []field // in the order they were added, nil until one is
+	fieldColors map[string]string
+	recent      *ringBuffer
+	routes      []route
+	outputs     []route // additional outputs, see AddOutput
+}
+
+var emptyState loggerState
+
+// loadState returns the current state of the logger
+func (l *Logger) loadState() *loggerState {
+	if s := l.state.Load(); s != nil {
+		return s
+	}
+	return &emptyState
+}
+
+// updateState applies fn to a copy of the state and stores the result. Updates
+// are serialized by mu, fn must replace rather than modify maps and slices.
+func (l *Logger) updateState(fn func(s *loggerState)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	s := *l.loadState()
+	fn(&s)
+	l.state.Store(&s)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestStateConcurrentUpdates(t *testing.T) {
+	logger, out := newTestLogger(WithRecent(10))
+	logger = logger.AddField("service", "api")
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				logger.Info("request served")
+			}
+		}()
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				logger.SetFieldColor("k"+strconv.Itoa(g), "\033[31m")
+				logger.KeepRecent(10 + i%5)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	for _, line := range lines(out) {
+		if !strings.HasSuffix(line, `request served, service: "api"`) {
+			t.Fatalf("line %q lost the logger's fields", line)
+		}
+	}
+}
+
+func BenchmarkConcurrentLogging(b *testing.B) {
+	logger := New(WithOutput(nopWriter{})).AddField("service", "api").AddField("region", "eu")
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			logger.Info("request served")
+		}
+	})
+}
+
+func BenchmarkConcurrentLoggingWithUpdates(b *testing.B) {
+	logger := New(WithOutput(nopWriter{})).AddField("service", "api")
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			if i%1000 == 0 {
+				logger.SetFieldColor("service", "\033[36m")
+			}
+			logger.Info("request served")
+		}
+	})
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import "sync/atomic"
+
+// levelStats holds per-level entry counters shared between a logger and its derived loggers
+type levelStats struct {
+	counts   [TraceLevel + 1]uint64
+	canceled uint64 // entries dropped for a done context
+	sampled  uint64 // entries dropped by the sampler
+}
+
+// inc increments the counter for the given level, ignoring out-of-range levels
+func (s *levelStats) inc(level LogLevel) {
+	if level < DebugLevel || level > TraceLevel {
+		return
+	}
+	atomic.AddUint64(&s.counts[level], 1)
+}
+
+// Stats returns the number of entries logged so far for each level
+func (l *Logger) Stats() map[LogLevel]uint64 {
+	stats := make(map[LogLevel]uint64, len(l.stats.counts))
+	for level := range l.stats.counts {
+		stats[LogLevel(level)] = atomic.LoadUint64(&l.stats.counts[level])
+	}
+	return stats
+}
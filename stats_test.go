@@ -0,0 +1,35 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import "testing"
+
+func TestStats(t *testing.T) {
+	logger, _ := newTestLogger(WithLevel(DebugLevel))
+	derived := logger.AddField("k", "v")
+
+	logger.Debug("d")
+	logger.Info("i1")
+	derived.Info("i2")
+	logger.Warn("w")
+	derived.Error("e1")
+	logger.Errorf("e%d", 2)
+	logger.Error("e3")
+	logger.Trace("t")
+
+	want := map[LogLevel]uint64{DebugLevel: 1, InfoLevel: 2, WarnLevel: 1, ErrorLevel: 3, PanicLevel: 0, TraceLevel: 1}
+	got := logger.Stats()
+	if len(got) != len(want) {
+		t.Fatalf("Stats() = %v, want %v", got, want)
+	}
+	for level, n := range want {
+		if got[level] != n {
+			t.Errorf("Stats()[%v] = %d, want %d", level, got[level], n)
+		}
+	}
+	if derived.Stats()[ErrorLevel] != 3 {
+		t.Errorf("derived logger does not share the counters")
+	}
+}
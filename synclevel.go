@@ -0,0 +1,35 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+// WithSyncLevel syncs the log file and the per-level files to disk right after
+// an entry at level or above, up to PanicLevel, is written, e.g. ErrorLevel so
+// errors survive a crash while other entries are left to the OS or to
+// WithFileBuffer. Buffered file output is flushed first.
+func WithSyncLevel(level LogLevel) Option {
+	return func(l *Logger) {
+		l.syncLevel = level
+		l.syncOnLevel = true
+	}
+}
+
+// syncFiles flushes and syncs the files that received an entry at level, must be called with outMu held
+func (l *Logger) syncFiles(level LogLevel) {
+	if l.file != nil {
+		if err := l.Flush(); err != nil {
+			l.reportError(err)
+		}
+		if err := l.file.Sync(); err != nil {
+			l.reportError(err)
+		}
+	}
+	for _, f := range l.levelFiles {
+		if f.matches(level) {
+			if err := f.sync(); err != nil {
+				l.reportError(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithSyncLevel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger, _ := newTestLogger(WithFile(path), WithFileBuffer(4096, time.Hour), WithSyncLevel(ErrorLevel))
+	defer logger.Close()
+
+	read := func() string {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	logger.Info("buffered")
+	logger.Warn("still buffered")
+	if got := read(); got != "" {
+		t.Errorf("file = %q after entries below the sync level, want them buffered", got)
+	}
+
+	logger.AddField("k", "v").Error("synced")
+	got := read()
+	for _, want := range []string{"buffered", "still buffered", "synced"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("file %q is missing %q after an Error", got, want)
+		}
+	}
+
+	logger.Info("buffered again")
+	if strings.Contains(read(), "buffered again") {
+		t.Errorf("Info after the Error was flushed")
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+//go:build !windows && !plan9
+
+package trolog
+
+import (
+	"log/syslog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	syslogQueueSize      = 1024
+	syslogRedialInterval = time.Second // limits how often a lost connection is re-dialed
+)
+
+// syslogMessage is an entry queued for the syslog daemon
+type syslogMessage struct {
+	level LogLevel
+	text  string
+}
+
+// syslogSink writes entries to a syslog daemon from a background goroutine,
+// reconnecting when the connection drops
+type syslogSink struct {
+	network, addr, tag string
+
+	mu      sync.RWMutex // guards closed against sends on a closed queue
+	closed  bool
+	queue   chan syslogMessage
+	done    chan struct{}
+	dropped uint64
+
+	// Used by the run goroutine only
+	writer        *syslog.Writer
+	lastDial      time.Time
+	dialedClosing bool
+	closeErr      error
+}
+
+// WithSyslog sends entries at or above the logger's level to the syslog daemon at
+// addr over network ("udp", "tcp" or "unix"), or to the local daemon if both are
+// empty. Levels map to syslog severities. The daemon is dialed when the first
+// entry is sent, and entries are queued and written in the background, so a slow
+// daemon does not hold up the logger. If it is unreachable or the queue is full,
+// entries are dropped and the connection is retried at most once per second.
+func WithSyslog(network, addr, tag string) Option {
+	return withSink(func() sink {
+		s := &syslogSink{
+			network: network,
+			addr:    addr,
+			tag:     tag,
+			queue:   make(chan syslogMessage, syslogQueueSize),
+			done:    make(chan struct{}),
+		}
+		go s.run()
+		return s
+	})
+}
+
+func (s *syslogSink) write(entry Entry, line []byte) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		atomic.AddUint64(&s.dropped, 1)
+		return
+	}
+	select {
+	case s.queue <- syslogMessage{level: entry.Level, text: string(line)}:
+	default:
+		atomic.AddUint64(&s.dropped, 1)
+	}
+}
+
+func (s *syslogSink) droppedEntries() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// run writes queued messages until the queue is closed, then closes the connection
+func (s *syslogSink) run() {
+	defer close(s.done)
+
+	for msg := range s.queue {
+		if !s.send(msg) {
+			atomic.AddUint64(&s.dropped, 1)
+		}
+	}
+
+	if s.writer != nil {
+		s.closeErr = s.writer.Close()
+	}
+}
+
+// send writes msg to the daemon, dialing it first if needed. Once the sink is
+// closing, it is dialed at most once more so Close does not wait for a dead daemon.
+func (s *syslogSink) send(msg syslogMessage) bool {
+	if s.writer == nil {
+		closing := s.closing()
+		if time.Since(s.lastDial) < syslogRedialInterval || closing && s.dialedClosing {
+			return false
+		}
+		s.lastDial = time.Now()
+		s.dialedClosing = closing
+		writer, err := syslog.Dial(s.network, s.addr, syslog.LOG_USER, s.tag)
+		if err != nil {
+			return false
+		}
+		s.writer = writer
+	}
+
+	// syslog.Writer reconnects once by itself; if that fails too, redial later
+	if err := writeSyslog(s.writer, msg.level, msg.text); err != nil {
+		_ = s.writer.Close()
+		s.writer = nil
+		return false
+	}
+	return true
+}
+
+// closing reports whether Close has been called
+func (s *syslogSink) closing() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.closed
+}
+
+// Close writes the entries still queued, then closes the connection
+func (s *syslogSink) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	close(s.queue)
+	s.mu.Unlock()
+
+	<-s.done
+	return s.closeErr
+}
+
+// writeSyslog writes message with the syslog severity matching level
+func writeSyslog(w *syslog.Writer, level LogLevel, message string) error {
+	switch syslogSeverity(level) {
+	case syslog.LOG_CRIT:
+		return w.Crit(message)
+	case syslog.LOG_ERR:
+		return w.Err(message)
+	case syslog.LOG_WARNING:
+		return w.Warning(message)
+	case syslog.LOG_INFO:
+		return w.Info(message)
+	default:
+		return w.Debug(message)
+	}
+}
+
+// syslogSeverity maps a LogLevel to its syslog severity
+func syslogSeverity(level LogLevel) syslog.Priority {
+	return syslog.Priority(syslogLevel(level))
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+//go:build !windows && !plan9
+
+package trolog
+
+import (
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSyslogSeverities(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	logger := New(WithOutput(io.Discard), WithLevel(DebugLevel), WithSyslog("udp", conn.LocalAddr().String(), "app"))
+	logger.Debug("debug")
+	logger.Info("info")
+	logger.Warn("warn")
+	logger.Error("error")
+	logger.Panic("panic")
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The priority is the facility (user, 1) times 8 plus the severity
+	want := []string{"<15>", "<14>", "<12>", "<11>", "<10>"}
+	buf := make([]byte, 2048)
+	for i, prefix := range want {
+		_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("reading message %d: %v", i, err)
+		}
+		msg := string(buf[:n])
+		if !strings.HasPrefix(msg, prefix) {
+			t.Errorf("message %d = %q, want priority %s", i, msg, prefix)
+		}
+		if !strings.Contains(msg, "app[") {
+			t.Errorf("message %d = %q has no tag", i, msg)
+		}
+	}
+}
+
+func TestSyslogUnreachable(t *testing.T) {
+	logger := New(WithOutput(io.Discard), WithSyslog("tcp", "127.0.0.1:1", "app"))
+	logger.Info("nobody listens")
+	_ = logger.Close()
+	logger.Info("after close")
+	if d := logger.Drops(); d.Sinks != 2 {
+		t.Errorf("Drops().Sinks = %d, want 2", d.Sinks)
+	}
+}
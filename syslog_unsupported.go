@@ -0,0 +1,12 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+//go:build windows || plan9
+
+package trolog
+
+// WithSyslog is not supported on this platform and leaves the logger unchanged
+func WithSyslog(network, addr, tag string) Option {
+	return func(l *Logger) {}
+}
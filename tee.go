@@ -0,0 +1,44 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import "io"
+
+// teeSink copies plain entries to a writer until stopped
+type teeSink struct {
+	w       io.Writer
+	stopped bool // guarded by the logger's outMu, like every write
+}
+
+// Tee returns a derived logger that writes its entries to w as well as to the
+// logger's own outputs, e.g. to capture the logs of one request or test in a
+// buffer. Lines are written uncolored and filtered by level like the terminal.
+// The returned function stops the copying; the derived logger keeps logging to
+// the regular outputs afterwards. Loggers derived from the tee logger share w.
+func (l *Logger) Tee(w io.Writer) (*Logger, func()) {
+	s := &teeSink{w: w}
+	newLogger := l.clone()
+	newLogger.loadState().fields = l.loadState().fields
+	newLogger.sinks = append(l.sinks[:len(l.sinks):len(l.sinks)], s)
+
+	stop := func() {
+		l.outMu.Lock()
+		s.stopped = true
+		l.outMu.Unlock()
+	}
+	return newLogger, stop
+}
+
+func (s *teeSink) write(entry Entry, line []byte) {
+	if s.stopped {
+		return
+	}
+	_, _ = s.w.Write(line)
+}
+
+// Close does nothing, the writer belongs to the caller
+func (s *teeSink) Close() error {
+	return nil
+}
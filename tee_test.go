@@ -0,0 +1,36 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestTee(t *testing.T) {
+	logger, out := newTestLogger(WithColor(true))
+	logger = logger.AddField("app", "api")
+
+	var captured bytes.Buffer
+	tee, stop := logger.Tee(&captured)
+	tee.Info("one")
+	tee.AddField("k", "v").Warn("two")
+	tee.Debug("below the level")
+	logger.Info("not teed")
+	stop()
+	tee.Info("after stop")
+
+	got := lines(&captured)
+	if len(got) != 2 || !strings.HasSuffix(got[0], `one, app: "api"`) || !strings.HasSuffix(got[1], `two, app: "api" k: "v"`) {
+		t.Errorf("captured %q, want the two teed entries", got)
+	}
+	if strings.Contains(captured.String(), "\033[") {
+		t.Errorf("captured %q is colored", captured.String())
+	}
+	if n := len(lines(out)); n != 4 {
+		t.Errorf("output has %d lines, want all 4 entries at the level", n)
+	}
+}
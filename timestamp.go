@@ -0,0 +1,115 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"strconv"
+	"time"
+)
+
+// Special time formats accepted by WithTimeFormat and the formatters' TimeFormat,
+// writing the time as an integer count since the Unix epoch. JSON output emits
+// these as numbers rather than strings.
+const (
+	TimeUnix      = "unix"      // seconds, e.g. 1728534284
+	TimeUnixMilli = "unixmilli" // milliseconds, e.g. 1728534284123
+	TimeUnixMicro = "unixmicro" // microseconds
+	TimeUnixNano  = "unixnano"  // nanoseconds
+)
+
+// TimeISO8601 is an ISO 8601 layout that always writes the numeric offset, e.g.
+// "+00:00" where time.RFC3339 writes "Z" for UTC
+const TimeISO8601 = "2006-01-02T15:04:05-07:00"
+
+// WithISO8601 writes timestamps in loc with the TimeISO8601 layout, so every
+// timestamp carries an explicit offset such as "2024-10-10T04:24:44+00:00" for
+// time.UTC. A nil loc uses the local time zone.
+func WithISO8601(loc *time.Location) Option {
+	return func(l *Logger) {
+		WithTimeFormat(TimeISO8601)(l)
+		l.location = loc
+	}
+}
+
+// WithLocation writes timestamps in loc, taking precedence over WithUTC. A nil
+// loc uses the local time zone.
+func WithLocation(loc *time.Location) Option {
+	return func(l *Logger) {
+		l.location = loc
+	}
+}
+
+// TimePrecision selects the fractional seconds of RFC3339 timestamps
+type TimePrecision int
+
+const (
+	PrecisionSeconds TimePrecision = iota // 2024-10-10T10:24:44+06:00
+	PrecisionMilli                        // 2024-10-10T10:24:44.123+06:00
+	PrecisionMicro                        // 2024-10-10T10:24:44.123456+06:00
+	PrecisionNano                         // 2024-10-10T10:24:44.123456789+06:00
+)
+
+// WithTimePrecision uses RFC3339 timestamps with a fixed number of fractional
+// digits, keeping high-frequency entries ordered without a custom layout. It
+// replaces any time format set before it.
+func WithTimePrecision(precision TimePrecision) Option {
+	switch precision {
+	case PrecisionMilli:
+		return WithTimeFormat("2006-01-02T15:04:05.000Z07:00")
+	case PrecisionMicro:
+		return WithTimeFormat("2006-01-02T15:04:05.000000Z07:00")
+	case PrecisionNano:
+		return WithTimeFormat("2006-01-02T15:04:05.000000000Z07:00")
+	default:
+		return WithTimeFormat(time.RFC3339)
+	}
+}
+
+// formatTimestamp formats t with layout, which may be one of the epoch formats
+func formatTimestamp(t time.Time, layout string) string {
+	return string(appendTimestamp(nil, t, layout))
+}
+
+// appendTimestamp appends t formatted with layout to dst
+func appendTimestamp(dst []byte, t time.Time, layout string) []byte {
+	switch layout {
+	case TimeUnix:
+		return strconv.AppendInt(dst, t.Unix(), 10)
+	case TimeUnixMilli:
+		return strconv.AppendInt(dst, t.UnixMilli(), 10)
+	case TimeUnixMicro:
+		return strconv.AppendInt(dst, t.UnixMicro(), 10)
+	case TimeUnixNano:
+		return strconv.AppendInt(dst, t.UnixNano(), 10)
+	}
+	return t.AppendFormat(dst, layout)
+}
+
+// WithElapsedTime replaces timestamps with the time elapsed since the logger was
+// created, rounded to precision, e.g. "+1.234s" for time.Millisecond. Loggers
+// derived from it count from the same start. A later WithTimeFormat overrides it.
+func WithElapsedTime(precision time.Duration) Option {
+	return func(l *Logger) {
+		if precision <= 0 {
+			precision = time.Millisecond
+		}
+		l.elapsed = precision
+		l.enc.epochTime = false
+	}
+}
+
+// formatElapsed formats d rounded to precision with a leading plus sign
+func formatElapsed(d, precision time.Duration) string {
+	return "+" + d.Round(precision).String()
+}
+
+// isEpochLayout reports whether layout is one of the epoch formats
+func isEpochLayout(layout string) bool {
+	switch layout {
+	case TimeUnix, TimeUnixMilli, TimeUnixMicro, TimeUnixNano:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEpochTimestamps(t *testing.T) {
+	when := testTime.Add(123456789 * time.Nanosecond)
+	tests := []struct {
+		layout string
+		parse  func(n int64) time.Time
+		want   time.Time
+	}{
+		{TimeUnix, func(n int64) time.Time { return time.Unix(n, 0) }, when.Truncate(time.Second)},
+		{TimeUnixMilli, time.UnixMilli, when.Truncate(time.Millisecond)},
+		{TimeUnixMicro, time.UnixMicro, when.Truncate(time.Microsecond)},
+		{TimeUnixNano, func(n int64) time.Time { return time.Unix(0, n) }, when},
+	}
+	for _, tt := range tests {
+		// Text lines carry the number as the timestamp
+		logger, out := newTestLogger(WithTimeFormat(tt.layout), WithClock(&fixedClock{when}), WithLayout("{time} {msg}"))
+		logger.Info("m")
+		n, err := strconv.ParseInt(strings.TrimSuffix(out.String(), " m\n"), 10, 64)
+		if err != nil {
+			t.Fatalf("%s: line %q has no integer timestamp: %v", tt.layout, out.String(), err)
+		}
+		if got := tt.parse(n); !got.Equal(tt.want) {
+			t.Errorf("%s: text timestamp parses back to %v, want %v", tt.layout, got, tt.want)
+		}
+
+		// JSON carries it as a number
+		logger, out = newTestLogger(WithTimeFormat(tt.layout), WithClock(&fixedClock{when}), WithFormat(FormatJSON))
+		logger.Info("m")
+		var entry struct{ Time json.Number }
+		if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+			t.Fatalf("%s: line %q: %v", tt.layout, out.String(), err)
+		}
+		if !strings.Contains(out.String(), `"time":`+entry.Time.String()) {
+			t.Errorf("%s: JSON time in %q is not a number", tt.layout, out.String())
+		}
+		n, err = entry.Time.Int64()
+		if err != nil || !tt.parse(n).Equal(tt.want) {
+			t.Errorf("%s: JSON time %q does not parse back to %v", tt.layout, entry.Time, tt.want)
+		}
+	}
+}
+
+func TestElapsedTime(t *testing.T) {
+	clock := &stepClock{t: testTime, step: 1234567 * time.Microsecond}
+	logger, out := newTestLogger(WithClock(clock), WithElapsedTime(time.Millisecond), WithLayout("{time} {msg}"))
+	logger.Info("first")
+	logger.AddField("k", "v").Info("derived")
+
+	// The logger's creation takes the first reading of the clock
+	got := lines(out)
+	if want := "+1.235s first"; got[0] != want {
+		t.Errorf("line %q, want %q", got[0], want)
+	}
+	if want := `+2.469s derived, k: "v"`; got[1] != want {
+		t.Errorf("line %q, want %q counted from the same start", got[1], want)
+	}
+
+	logger, out = newTestLogger(WithClock(&stepClock{t: testTime, step: 1500 * time.Millisecond}), WithElapsedTime(time.Second), WithLayout("{time} {msg}"))
+	logger.Info("rounded")
+	if got := lines(out)[0]; got != "+2s rounded" {
+		t.Errorf("line %q, want the elapsed time rounded to seconds", got)
+	}
+
+	logger, out = newTestLogger(WithElapsedTime(time.Millisecond), WithTimeFormat(time.Kitchen))
+	logger.Info("overridden")
+	if !strings.Contains(out.String(), " 10:24AM overridden") {
+		t.Errorf("line %q, want WithTimeFormat to override elapsed timestamps", out.String())
+	}
+}
+
+func TestTimePrecision(t *testing.T) {
+	when := time.Date(2024, 10, 10, 10, 24, 44, 120000000, time.FixedZone("", 6*60*60))
+	tests := []struct {
+		precision TimePrecision
+		want      string
+	}{
+		{PrecisionSeconds, "2024-10-10T10:24:44+06:00"},
+		{PrecisionMilli, "2024-10-10T10:24:44.120+06:00"},
+		{PrecisionMicro, "2024-10-10T10:24:44.120000+06:00"},
+		{PrecisionNano, "2024-10-10T10:24:44.120000000+06:00"},
+	}
+	for _, tt := range tests {
+		logger, out := newTestLogger(WithTimePrecision(tt.precision), WithClock(&fixedClock{when}), WithUTC(false), WithLocation(when.Location()), WithLayout("{time}"))
+		logger.Info("m")
+		if got := lines(out)[0]; got != tt.want {
+			t.Errorf("precision %d: timestamp %q, want %q with trailing zeros kept", tt.precision, got, tt.want)
+		}
+
+		wantTime := when
+		if tt.precision == PrecisionSeconds {
+			wantTime = when.Truncate(time.Second)
+		}
+		if entry, err := Parse("ID:1 INFO " + tt.want + " m"); err != nil || !entry.Time.Equal(wantTime) {
+			t.Errorf("precision %d: Parse = %v, %v, want %v", tt.precision, entry.Time, err, wantTime)
+		}
+	}
+}
+
+func TestWithISO8601(t *testing.T) {
+	dhaka := time.FixedZone("BST", 6*60*60)
+	newYork := time.FixedZone("EDT", -4*60*60)
+	tests := []struct {
+		loc    *time.Location
+		want   string
+		offset int
+	}{
+		{time.UTC, "2024-10-10T10:24:44+00:00", 0},
+		{dhaka, "2024-10-10T16:24:44+06:00", 6 * 60 * 60},
+		{newYork, "2024-10-10T06:24:44-04:00", -4 * 60 * 60},
+	}
+	for _, tt := range tests {
+		logger, out := newTestLogger(WithISO8601(tt.loc))
+		logger.Info("m")
+		if got := lines(out)[0]; !strings.Contains(got, " "+tt.want+" m") {
+			t.Errorf("%v: line %q, want timestamp %q", tt.loc, got, tt.want)
+		}
+
+		logger, out = newTestLogger(WithISO8601(tt.loc), WithFormat(FormatJSON))
+		logger.Info("m")
+		var entry struct{ Time string }
+		if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+			t.Fatal(err)
+		}
+		parsed, err := time.Parse(time.RFC3339, entry.Time)
+		if entry.Time != tt.want || err != nil || !parsed.Equal(testTime) {
+			t.Errorf("%v: JSON time %q (%v, %v), want %q", tt.loc, entry.Time, parsed, err, tt.want)
+		}
+		if _, offset := parsed.Zone(); offset != tt.offset {
+			t.Errorf("%v: offset %d, want %d", tt.loc, offset, tt.offset)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+// Package trologhttp logs requests served by net/http handlers with trolog and
+// hands each handler a logger scoped to its request.
+package trologhttp
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/mdtolhabinashraf/trolog"
+)
+
+// RequestIDHeader is the header a request ID is taken from if the client or a
+// proxy already set one
+const RequestIDHeader = "X-Request-Id"
+
+type contextKey struct{}
+
+// discard is returned by FromContext for contexts without a logger
+var discard = trolog.New(trolog.WithOutput(io.Discard))
+
+// Middleware wraps next so every request gets a logger with method, path and
+// request_id fields, available to the handler through FromContext. When the
+// handler returns, a "request completed" entry is logged with the status and
+// duration: at error level for 5xx responses, warn level for 4xx and info
+// level otherwise.
+//
+//	http.ListenAndServe(":8080", trologhttp.Middleware(logger, mux))
+func Middleware(l *trolog.Logger, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := l.Now()
+
+		requestID := r.Header.Get(RequestIDHeader)
+		if requestID == "" {
+			requestID = newRequestID()
+		}
+		// A single derived logger, closed with the request, so the outputs of l
+		// are not held open by loggers nobody closes
+		reqLogger := l.AddField("method", r.Method)
+		reqLogger.PushField("path", r.URL.Path)
+		reqLogger.PushField("request_id", requestID)
+		defer reqLogger.Close()
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r.WithContext(NewContext(r.Context(), reqLogger)))
+
+		level := trolog.InfoLevel
+		switch {
+		case rec.status >= 500:
+			level = trolog.ErrorLevel
+		case rec.status >= 400:
+			level = trolog.WarnLevel
+		}
+		reqLogger.Log(level, "request completed", trolog.Int("status", rec.status), trolog.Duration("duration", l.Now().Sub(start)))
+	})
+}
+
+// NewContext returns a copy of ctx carrying l, see FromContext
+func NewContext(ctx context.Context, l *trolog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the logger stored in ctx by Middleware or NewContext, or
+// a logger discarding everything if there is none, so it is always safe to use
+func FromContext(ctx context.Context) *trolog.Logger {
+	if l, ok := ctx.Value(contextKey{}).(*trolog.Logger); ok {
+		return l
+	}
+	return discard
+}
+
+// newRequestID returns a random 16 character hex ID
+func newRequestID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// statusRecorder remembers the status code written by a handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(p []byte) (int, error) {
+	r.wroteHeader = true
+	return r.ResponseWriter.Write(p)
+}
+
+// Flush sends buffered data to the client if the wrapped writer is an
+// http.Flusher, so streaming handlers keep working behind the middleware
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		r.wroteHeader = true
+		f.Flush()
+	}
+}
+
+// Hijack hands the connection to the handler if the wrapped writer is an
+// http.Hijacker, e.g. for WebSocket upgrades
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	conn, rw, err := h.Hijack()
+	if err == nil && !r.wroteHeader {
+		r.status = http.StatusSwitchingProtocols
+		r.wroteHeader = true
+	}
+	return conn, rw, err
+}
+
+// Unwrap returns the wrapped writer for http.ResponseController
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trologhttp
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mdtolhabinashraf/trolog"
+)
+
+// manualClock is a clock that only moves when a handler advances it
+type manualClock struct {
+	t time.Time
+}
+
+func (c *manualClock) Now() time.Time { return c.t }
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		status int
+		want   string
+	}{
+		{http.StatusOK, `INFO request completed, method: "GET" path: "/items" request_id: "req-1" status: "200" duration: "1.5s"`},
+		{http.StatusNotFound, `WARN request completed, method: "GET" path: "/items" request_id: "req-1" status: "404" duration: "1.5s"`},
+		{http.StatusBadGateway, `ERRO request completed, method: "GET" path: "/items" request_id: "req-1" status: "502" duration: "1.5s"`},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		clock := &manualClock{t: time.Date(2024, 10, 10, 10, 24, 44, 0, time.UTC)}
+		logger := trolog.New(trolog.WithOutput(trolog.StripANSI(&out)), trolog.WithClock(clock), trolog.WithLayout("{level} {msg}"))
+
+		handler := Middleware(logger, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			FromContext(r.Context()).Info("handling")
+			clock.t = clock.t.Add(1500 * time.Millisecond)
+			w.WriteHeader(tt.status)
+			w.WriteHeader(http.StatusTeapot) // Ignored, the first status counts
+		}))
+
+		req := httptest.NewRequest(http.MethodGet, "/items", nil)
+		req.Header.Set(RequestIDHeader, "req-1")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != tt.status {
+			t.Errorf("response status = %d, want %d", rec.Code, tt.status)
+		}
+		got := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+		if len(got) != 2 || got[0] != `INFO handling, method: "GET" path: "/items" request_id: "req-1"` || got[1] != tt.want {
+			t.Errorf("lines = %q, want the handler entry and %q", got, tt.want)
+		}
+	}
+}
+
+func TestMiddlewareDefaults(t *testing.T) {
+	var out bytes.Buffer
+	logger := trolog.New(trolog.WithOutput(trolog.StripANSI(&out)), trolog.WithLayout("{msg}"))
+	handler := Middleware(logger, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", nil))
+
+	line := out.String()
+	if !strings.Contains(line, `status: "200"`) {
+		t.Errorf("line %q, want status 200 for a handler that only writes a body", line)
+	}
+	i := strings.Index(line, `request_id: "`)
+	if i < 0 || len(line) < i+len(`request_id: "`)+16 || line[i+len(`request_id: "`)+16] != '"' {
+		t.Errorf("line %q, want a generated 16 character request ID", line)
+	}
+}
+
+func TestMiddlewareReleasesLogger(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log.gz")
+	logger := trolog.New(trolog.WithOutput(io.Discard), trolog.WithFile(path), trolog.WithGzip(true), trolog.WithLayout("{msg}"))
+	handler := Middleware(logger, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// The request's logger is closed with the request, so closing the logger
+	// closes the file and writes the gzip trailer
+	if err := logger.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text, err := io.ReadAll(zr); err != nil || !strings.Contains(string(text), "request completed") {
+		t.Errorf("gunzipped %q, %v", text, err)
+	}
+}
+
+func TestFromContextWithoutLogger(t *testing.T) {
+	if l := FromContext(context.Background()); l == nil {
+		t.Fatal("FromContext returned nil")
+	} else {
+		l.Info("discarded") // Must not panic
+	}
+}
+
+// hijackRecorder is a ResponseRecorder that can also be hijacked
+type hijackRecorder struct {
+	*httptest.ResponseRecorder
+	hijacked bool
+}
+
+func (r *hijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	r.hijacked = true
+	return nil, nil, nil
+}
+
+func TestMiddlewareFlushAndHijack(t *testing.T) {
+	var out bytes.Buffer
+	logger := trolog.New(trolog.WithOutput(trolog.StripANSI(&out)), trolog.WithLayout("{msg}"))
+
+	flushed := httptest.NewRecorder()
+	Middleware(logger, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f, ok := w.(http.Flusher)
+		if !ok {
+			t.Fatal("writer is not an http.Flusher")
+		}
+		f.Flush()
+	})).ServeHTTP(flushed, httptest.NewRequest(http.MethodGet, "/", nil))
+	if !flushed.Flushed {
+		t.Error("Flush was not passed to the wrapped writer")
+	}
+
+	hijacked := &hijackRecorder{ResponseRecorder: httptest.NewRecorder()}
+	Middleware(logger, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h, ok := w.(http.Hijacker)
+		if !ok {
+			t.Fatal("writer is not an http.Hijacker")
+		}
+		if _, _, err := h.Hijack(); err != nil {
+			t.Errorf("Hijack() error = %v", err)
+		}
+	})).ServeHTTP(hijacked, httptest.NewRequest(http.MethodGet, "/", nil))
+	if !hijacked.hijacked {
+		t.Error("Hijack was not passed to the wrapped writer")
+	}
+	if !strings.Contains(out.String(), `status: "101"`) {
+		t.Errorf("output %q, want status 101 for a hijacked connection", out.String())
+	}
+
+	Middleware(logger, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, _, err := w.(http.Hijacker).Hijack(); !errors.Is(err, http.ErrNotSupported) {
+			t.Errorf("Hijack() error = %v, want http.ErrNotSupported", err)
+		}
+	})).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
@@ -0,0 +1,17 @@
+module github.com/mdtolhabinashraf/trolog/trologotel
+
+go 1.23.2
+
+require (
+	github.com/mdtolhabinashraf/trolog v0.1.0
+	go.opentelemetry.io/otel/trace v1.31.0
+)
+
+require go.opentelemetry.io/otel v1.31.0 // indirect
+
+// v0.1.0 is the first trolog release with WithContextExtractor and is not
+// tagged yet: it must be cut from the commit that adds this module, before
+// trologotel is tagged, or importers cannot resolve the requirement above.
+// Builds inside the repository use the trolog next to this module; the replace
+// has no effect for importers.
+replace github.com/mdtolhabinashraf/trolog => ../
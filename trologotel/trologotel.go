@@ -0,0 +1,41 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+// Package trologotel adds OpenTelemetry trace and span IDs to trolog entries.
+// It is a separate module so the core logger stays free of dependencies.
+package trologotel
+
+import (
+	"context"
+
+	"github.com/mdtolhabinashraf/trolog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Field names used for the IDs of the active span
+const (
+	TraceIDKey = "trace_id"
+	SpanIDKey  = "span_id"
+)
+
+// TraceFields returns the trace_id and span_id of the span active in ctx,
+// or nil if there is none. It can be registered with trolog.WithContextExtractor.
+func TraceFields(ctx context.Context) map[string]string {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+	return map[string]string{
+		TraceIDKey: sc.TraceID().String(),
+		SpanIDKey:  sc.SpanID().String(),
+	}
+}
+
+// WithTrace adds trace_id and span_id to entries logged with the Ctx methods
+//
+//	logger := trolog.New(trologotel.WithTrace())
+//	logger.InfoCtx(ctx, "handled request")
+func WithTrace() trolog.Option {
+	return trolog.WithContextExtractor(TraceFields)
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trologotel
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mdtolhabinashraf/trolog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func spanContext(t *testing.T) context.Context {
+	t.Helper()
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	if err != nil {
+		t.Fatal(err)
+	}
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sc := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled})
+	return trace.ContextWithSpanContext(context.Background(), sc)
+}
+
+func TestTraceFields(t *testing.T) {
+	fields := TraceFields(spanContext(t))
+	if got := fields[TraceIDKey]; got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("trace_id = %q", got)
+	}
+	if got := fields[SpanIDKey]; got != "00f067aa0ba902b7" {
+		t.Errorf("span_id = %q", got)
+	}
+	if fields := TraceFields(context.Background()); fields != nil {
+		t.Errorf("TraceFields without a span = %v, want nil", fields)
+	}
+}
+
+func TestWithTrace(t *testing.T) {
+	var out bytes.Buffer
+	logger := trolog.New(trolog.WithOutput(&out), trolog.WithFormat(trolog.FormatJSON), WithTrace())
+
+	logger.InfoCtx(spanContext(t), "handled request")
+	line := out.String()
+	for _, want := range []string{`"trace_id":"4bf92f3577b34da6a3ce929d0e0e4736"`, `"span_id":"00f067aa0ba902b7"`} {
+		if !strings.Contains(line, want) {
+			t.Errorf("line %q does not contain %s", line, want)
+		}
+	}
+
+	out.Reset()
+	logger.InfoCtx(context.Background(), "no span")
+	if strings.Contains(out.String(), TraceIDKey) {
+		t.Errorf("line without a span has a trace ID: %q", out.String())
+	}
+}
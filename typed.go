@@ -0,0 +1,178 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+type fieldKind int
+
+const (
+	kindString fieldKind = iota
+	kindInt
+	kindUint
+	kindFloat
+	kindBool
+	kindDuration
+	kindTime
+	kindAny
+)
+
+// Field is a typed key and value passed to the level methods, e.g.
+//
+//	logger.Info("request served", trolog.String("path", path), trolog.Int("status", 200))
+//
+// Unlike AddField, fields apply to a single entry and their values are written
+// straight into the line, so strings, integers, floats and booleans are logged
+// without allocating. They override logger fields with the same key.
+type Field struct {
+	Key   string
+	kind  fieldKind
+	str   string
+	num   int64
+	value interface{}
+}
+
+// String returns a string field
+func String(key, value string) Field {
+	return Field{Key: key, kind: kindString, str: value}
+}
+
+// Int returns an integer field
+func Int(key string, value int) Field {
+	return Field{Key: key, kind: kindInt, num: int64(value)}
+}
+
+// Int64 returns a 64-bit integer field
+func Int64(key string, value int64) Field {
+	return Field{Key: key, kind: kindInt, num: value}
+}
+
+// Uint64 returns an unsigned 64-bit integer field
+func Uint64(key string, value uint64) Field {
+	return Field{Key: key, kind: kindUint, num: int64(value)}
+}
+
+// Float64 returns a float field, written like strconv.FormatFloat(value, 'f', -1, 64)
+func Float64(key string, value float64) Field {
+	return Field{Key: key, kind: kindFloat, num: int64(math.Float64bits(value))}
+}
+
+// Bool returns a boolean field
+func Bool(key string, value bool) Field {
+	f := Field{Key: key, kind: kindBool}
+	if value {
+		f.num = 1
+	}
+	return f
+}
+
+// Duration returns a duration field, written like time.Duration.String
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, kind: kindDuration, num: int64(value)}
+}
+
+// Time returns a time field, written in RFC3339 with nanoseconds
+func Time(key string, value time.Time) Field {
+	return Field{Key: key, kind: kindTime, value: value}
+}
+
+// Err returns an "error" field holding the message of err
+func Err(err error) Field {
+	return Field{Key: "error", kind: kindAny, value: err}
+}
+
+// Any returns a field converted like a value passed to AddField
+func Any(key string, value interface{}) Field {
+	return Field{Key: key, kind: kindAny, value: value}
+}
+
+// appendValue appends the text of a non-string field to dst
+func (f *Field) appendValue(dst []byte) []byte {
+	switch f.kind {
+	case kindInt:
+		return strconv.AppendInt(dst, f.num, 10)
+	case kindUint:
+		return strconv.AppendUint(dst, uint64(f.num), 10)
+	case kindFloat:
+		return strconv.AppendFloat(dst, math.Float64frombits(uint64(f.num)), 'f', -1, 64)
+	case kindBool:
+		return strconv.AppendBool(dst, f.num == 1)
+	case kindDuration:
+		return append(dst, time.Duration(f.num).String()...)
+	case kindTime:
+		return f.value.(time.Time).AppendFormat(dst, time.RFC3339Nano)
+	case kindAny:
+		if f.value == nil {
+			return append(dst, "nil"...)
+		}
+		return append(dst, valueToString(f.value)...)
+	default:
+		return append(dst, f.str...)
+	}
+}
+
+// scratch holds the temporary data of one entry, pooled so logging does not
+// allocate. Strings returned by arenaString point into the arena and are only
+// valid until the scratch is put back.
+type scratch struct {
+	arena  []byte  // timestamp and typed field values
+	fields []field // logger fields merged with typed fields
+}
+
+var scratchPool = sync.Pool{
+	New: func() interface{} {
+		return &scratch{arena: make([]byte, 0, 128)}
+	},
+}
+
+// getScratch returns an empty scratch from the pool
+func getScratch() *scratch {
+	sc := scratchPool.Get().(*scratch)
+	sc.arena = sc.arena[:0]
+	clear(sc.fields)
+	sc.fields = sc.fields[:0]
+	return sc
+}
+
+// arenaString returns the arena from start as a string without copying it.
+// Appending to the arena later leaves the string intact, as a grown arena is
+// a new array and bytes before the end are never rewritten until reuse.
+func (sc *scratch) arenaString(start int) string {
+	if start == len(sc.arena) {
+		return ""
+	}
+	return unsafe.String(&sc.arena[start], len(sc.arena)-start)
+}
+
+// mergeTyped returns the logger fields with the typed fields of an entry set,
+// keys qualified and checked like those of AddField
+func (l *Logger) mergeTyped(sc *scratch, fields []field, typed []Field) []field {
+	sc.fields = append(sc.fields, fields...)
+	for i := range typed {
+		f := &typed[i]
+		key, ok := l.fieldKey(f.Key)
+		if !ok {
+			continue
+		}
+		value := f.str
+		if f.kind != kindString {
+			start := len(sc.arena)
+			sc.arena = f.appendValue(sc.arena)
+			value = sc.arenaString(start)
+		}
+
+		sc.fields = setFieldInPlace(sc.fields, key, value)
+		if f.kind == kindAny {
+			sc.fields = l.appendErrorChain(sc.fields, key, f.value)
+		}
+	}
+	return sc.fields
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTypedFields(t *testing.T) {
+	tests := []struct {
+		field Field
+		want  string
+	}{
+		{String("s", "a b"), `a b`},
+		{Int("i", -42), `-42`},
+		{Int64("i64", math.MinInt64), `-9223372036854775808`},
+		{Uint64("u64", math.MaxUint64), `18446744073709551615`},
+		{Float64("f", 1.25), `1.25`},
+		{Float64("f", 1e21), `1000000000000000000000`},
+		{Bool("b", true), `true`},
+		{Bool("b", false), `false`},
+		{Duration("d", 1500*time.Millisecond), `1.5s`},
+		{Time("t", testTime.Add(123*time.Nanosecond)), `2024-10-10T10:24:44.000000123Z`},
+		{Err(errors.New("denied")), `denied`},
+		{Err(nil), `nil`},
+		{Any("a", []int{1, 2}), valueToString([]int{1, 2})},
+	}
+	for _, tt := range tests {
+		logger, out := newTestLogger(WithRecent(1))
+		logger.Info("typed", tt.field)
+		if got := logger.Recent()[0].Fields[tt.field.Key]; got != tt.want {
+			t.Errorf("%s field = %q, want %q (line %q)", tt.field.Key, got, tt.want, out.String())
+		}
+	}
+}
+
+func TestTypedFieldsOverrideLoggerFields(t *testing.T) {
+	logger, out := newTestLogger()
+	logger.AddField("status", "pending").Info("done", Int("status", 200))
+	if got := out.String(); !strings.HasSuffix(got, `done, status: "200"`+"\n") {
+		t.Errorf("line %q, want the typed field to override the logger field", got)
+	}
+}
+
+func TestTypedFieldsJSON(t *testing.T) {
+	logger, out := newTestLogger(WithFormat(FormatJSON))
+	logger.Info("typed", String("s", `q"uote`), Int("i", 7), Bool("b", true), Float64("f", 0.5))
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &entry); err != nil {
+		t.Fatalf("line %q is not JSON: %v", out.String(), err)
+	}
+	for key, want := range map[string]string{"s": `q"uote`, "i": "7", "b": "true", "f": "0.5"} {
+		if got := entry[key]; got != want {
+			t.Errorf("%s = %v, want %q", key, got, want)
+		}
+	}
+}
+
+func TestTypedFieldsAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("sync.Pool allocates under -race")
+	}
+	fields := []Field{String("path", "/api"), Int("status", 200), Float64("ratio", 0.25), Bool("cached", true), Duration("took", time.Millisecond)}
+	for _, format := range []Format{FormatText, FormatJSON} {
+		logger := New(WithOutput(nopWriter{}), WithFormat(format)).AddField("service", "api")
+		if allocs := testing.AllocsPerRun(100, func() { logger.Info("request served", fields...) }); allocs != 0 {
+			t.Errorf("format %v: typed fields allocate %v times, want 0", format, allocs)
+		}
+	}
+}
+
+func BenchmarkTypedFields(b *testing.B) {
+	for _, bench := range []struct {
+		name   string
+		format Format
+	}{
+		{"text", FormatText},
+		{"json", FormatJSON},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			logger := New(WithOutput(nopWriter{}), WithFormat(bench.format)).AddField("service", "api")
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				logger.Info("request served", String("path", "/api"), Int("status", 200), Duration("took", time.Millisecond))
+			}
+		})
+	}
+}
+
+func BenchmarkFieldlessJSON(b *testing.B) {
+	logger := New(WithOutput(nopWriter{}), WithFormat(FormatJSON))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Info("request served")
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// integerToString formats any integer type without going through int, so no
+// precision is lost for 64-bit values on 32-bit platforms. Other values are
+// formatted by valueToString.
+func integerToString(value interface{}) string {
+	return formatInteger(value, 10)
+}
+
+// formatInteger formats any integer type in the given base, other values with valueToString
+func formatInteger(value interface{}, base int) string {
+	switch v := value.(type) {
+	case int:
+		return strconv.FormatInt(int64(v), base)
+	case int8:
+		return strconv.FormatInt(int64(v), base)
+	case int16:
+		return strconv.FormatInt(int64(v), base)
+	case int32:
+		return strconv.FormatInt(int64(v), base)
+	case int64:
+		return strconv.FormatInt(v, base)
+	case uint:
+		return strconv.FormatUint(uint64(v), base)
+	case uint8:
+		return strconv.FormatUint(uint64(v), base)
+	case uint16:
+		return strconv.FormatUint(uint64(v), base)
+	case uint32:
+		return strconv.FormatUint(uint64(v), base)
+	case uint64:
+		return strconv.FormatUint(v, base)
+	case uintptr:
+		return strconv.FormatUint(uint64(v), base)
+	}
+	return valueToString(value)
+}
+
+// stringer is fmt.Stringer, without importing fmt
+type stringer interface {
+	String() string
+}
+
+// isNilPointer reports whether value holds a nil pointer, whose methods would
+// likely panic when called
+func isNilPointer(value interface{}) bool {
+	rv := reflect.ValueOf(value)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
+
+// bytesToString returns b as text if it is printable UTF-8, otherwise as hex
+func bytesToString(b []byte) string {
+	if utf8.Valid(b) {
+		printable := true
+		for _, r := range string(b) {
+			if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+				printable = false
+				break
+			}
+		}
+		if printable {
+			return string(b)
+		}
+	}
+
+	out := make([]byte, 0, 2*len(b))
+	for _, c := range b {
+		out = append(out, hexDigits[c>>4], hexDigits[c&0x0f])
+	}
+	return string(out)
+}
+
+// compositeToString renders slices and arrays as [a, b, c] and maps as {k: v}
+// sorted by key, nested ones included, e.g. [[1, 2], [3]]. A nil value is "nil"
+// and other types are rendered by the fallback, see SetFallbackFormatter.
+func compositeToString(value interface{}) string {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		return string(appendList(nil, rv))
+	case reflect.Map:
+		return string(appendMap(nil, rv))
+	case reflect.Invalid:
+		return "nil"
+	}
+	if fn := fallbackFormatter.Load(); fn != nil {
+		return (*fn)(value)
+	}
+	return unsupportedToString(value)
+}
+
+// fallbackFormatter renders values of types without built-in support
+var fallbackFormatter atomic.Pointer[func(value interface{}) string]
+
+// SetFallbackFormatter sets how field and message values of types without
+// built-in support, such as channels, functions and structs, are rendered by
+// every logger. By default they are written with their Go type, e.g.
+// <unsupported:chan int>. A nil fn restores the default.
+func SetFallbackFormatter(fn func(value interface{}) string) {
+	if fn == nil {
+		fallbackFormatter.Store(nil)
+		return
+	}
+	fallbackFormatter.Store(&fn)
+}
+
+// unsupportedToString is the default fallback, naming the type of value
+func unsupportedToString(value interface{}) string {
+	return "<unsupported:" + reflect.TypeOf(value).String() + ">"
+}
+
+// appendList appends the elements of a slice or array to dst as [a, b, c]
+func appendList(dst []byte, rv reflect.Value) []byte {
+	dst = append(dst, '[')
+	for i := 0; i < rv.Len(); i++ {
+		if i > 0 {
+			dst = append(dst, ',', ' ')
+		}
+		dst = append(dst, valueToString(rv.Index(i).Interface())...)
+	}
+	return append(dst, ']')
+}
+
+// appendMap appends the entries of a map to dst as {k: v}, sorted by key for stable output
+func appendMap(dst []byte, rv reflect.Value) []byte {
+	type entry struct{ key, value string }
+	entries := make([]entry, 0, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		entries = append(entries, entry{valueToString(iter.Key().Interface()), valueToString(iter.Value().Interface())})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+
+	dst = append(dst, '{')
+	for i, e := range entries {
+		if i > 0 {
+			dst = append(dst, ',', ' ')
+		}
+		dst = append(dst, e.key...)
+		dst = append(dst, ':', ' ')
+		dst = append(dst, e.value...)
+	}
+	return append(dst, '}')
+}
+
+// durationToString renders a time.Duration compactly with at most one decimal,
+// e.g. "850ns", "12.5µs", "350ms", "1.2s" or "2m30s", as %s and %v do in
+// messages. Other values are converted with valueToString.
+func durationToString(value interface{}) string {
+	d, ok := value.(time.Duration)
+	if !ok {
+		return valueToString(value)
+	}
+
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	switch {
+	case d < time.Microsecond:
+		return sign + strconv.FormatInt(int64(d), 10) + "ns"
+	case d < time.Millisecond:
+		return sign + decimalString(float64(d)/float64(time.Microsecond)) + "µs"
+	case d < time.Second:
+		return sign + decimalString(float64(d)/float64(time.Millisecond)) + "ms"
+	case d < time.Minute:
+		return sign + decimalString(float64(d)/float64(time.Second)) + "s"
+	default:
+		return sign + d.Round(time.Second).String()
+	}
+}
+
+// decimalString formats f with one decimal, dropping a trailing ".0"
+func decimalString(f float64) string {
+	s := strconv.FormatFloat(f, 'f', 1, 64)
+	return strings.TrimSuffix(s, ".0")
+}
@@ -0,0 +1,259 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatMessageDurations(t *testing.T) {
+	tests := []struct {
+		format string
+		d      time.Duration
+		want   string
+	}{
+		{"took %v", 850 * time.Nanosecond, "took 850ns"},
+		{"took %v", 12500 * time.Nanosecond, "took 12.5µs"},
+		{"took %s", 350 * time.Millisecond, "took 350ms"},
+		{"took %v", 1200 * time.Millisecond, "took 1.2s"},
+		{"took %v", 42 * time.Second, "took 42s"},
+		{"took %v", 2*time.Minute + 30*time.Second, "took 2m30s"},
+		{"took %v", 90*time.Minute + 400*time.Millisecond, "took 1h30m0s"},
+		{"took %v", -1500 * time.Millisecond, "took -1.5s"},
+	}
+	for _, tt := range tests {
+		if got := formatMessage(tt.format, tt.d); got != tt.want {
+			t.Errorf("formatMessage(%q, %d) = %q, want %q", tt.format, int64(tt.d), got, tt.want)
+		}
+	}
+}
+
+func TestValueToStringSlices(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{[]string{"a", "b c"}, "[a, b c]"},
+		{[]int{1, -2, 3}, "[1, -2, 3]"},
+		{[]int{}, "[]"},
+		{[2]bool{true, false}, "[true, false]"},
+		{[][]int{{1, 2}, {3}}, "[[1, 2], [3]]"},
+		{[]byte("hello"), "hello"},
+		{[]byte{0x00, 0xff, 0x10}, "00ff10"},
+		{[]byte{}, ""},
+	}
+	for _, tt := range tests {
+		if got := valueToString(tt.value); got != tt.want {
+			t.Errorf("valueToString(%#v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+
+	logger, out := newTestLogger()
+	logger.AddField("tags", []string{"a", "b"}).AddField("raw", []byte{0x00, 0xad}).Info("m")
+	if want := `tags: "[a, b]" raw: "00ad"`; !strings.Contains(out.String(), want) {
+		t.Errorf("line %q does not contain %q", out.String(), want)
+	}
+}
+
+func TestValueToStringMaps(t *testing.T) {
+	value := map[string]int{"zeta": 3, "alpha": 1, "mid": 2}
+	want := "{alpha: 1, mid: 2, zeta: 3}"
+	for i := 0; i < 20; i++ { // Map iteration order varies between runs
+		if got := valueToString(value); got != want {
+			t.Fatalf("valueToString = %q, want %q", got, want)
+		}
+	}
+	if got := valueToString(map[int][]string{2: {"b"}, 1: {"a"}}); got != "{1: [a], 2: [b]}" {
+		t.Errorf("nested map = %q", got)
+	}
+
+	logger, out := newTestLogger()
+	logger.AddField("counts", value).Info("m")
+	if !strings.Contains(out.String(), `counts: "`+want+`"`) {
+		t.Errorf("text line %q does not contain the sorted map", out.String())
+	}
+
+	logger, out = newTestLogger(WithFormat(FormatJSON))
+	logger.AddField("counts", value).Info("m")
+	if !strings.Contains(out.String(), `"counts":"`+want+`"`) {
+		t.Errorf("JSON line %q does not contain the sorted map", out.String())
+	}
+}
+
+type testStringer struct{ name string }
+
+func (s *testStringer) String() string { return "stringer " + s.name }
+
+type testError struct{ code int }
+
+func (e *testError) Error() string { return "error " + strconv.Itoa(e.code) }
+
+func TestValueToStringMethods(t *testing.T) {
+	var nilStringer *testStringer
+	var nilError *testError
+	var nilInterface error
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{&testStringer{"a"}, "stringer a"},
+		{&testError{7}, "error 7"},
+		{nilStringer, "nil"},
+		{nilError, "nil"},
+		{error(nilError), "nil"},
+		{nilInterface, "nil"},
+		{time.Second, "1s"},
+	}
+	for _, tt := range tests {
+		if got := valueToString(tt.value); got != tt.want {
+			t.Errorf("valueToString(%#v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+
+	// Typed nils must not panic anywhere values are converted
+	logger, out := newTestLogger()
+	logger.AddField("s", nilStringer).Info("m", Any("e", nilError), Err(nilError))
+	if want := `s: "nil" e: "nil" error: "nil"`; !strings.Contains(out.String(), want) {
+		t.Errorf("line %q does not contain %q", out.String(), want)
+	}
+}
+
+func TestIntegerFieldsFullRange(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{int(-1), "-1"},
+		{int8(math.MinInt8), "-128"},
+		{int16(math.MaxInt16), "32767"},
+		{int32(math.MinInt32), "-2147483648"},
+		{int64(math.MaxInt64), "9223372036854775807"},
+		{int64(math.MinInt64), "-9223372036854775808"},
+		{uint(7), "7"},
+		{uint8(math.MaxUint8), "255"},
+		{uint16(math.MaxUint16), "65535"},
+		{uint32(math.MaxUint32), "4294967295"},
+		{uint64(math.MaxUint64), "18446744073709551615"},
+		{uintptr(42), "42"},
+	}
+	for _, tt := range tests {
+		if got := valueToString(tt.value); got != tt.want {
+			t.Errorf("valueToString(%T %v) = %q, want %q", tt.value, tt.value, got, tt.want)
+		}
+	}
+
+	if got := formatMessage("%d and %d", int64(math.MaxInt64), uint64(math.MaxUint64)); got != "9223372036854775807 and 18446744073709551615" {
+		t.Errorf("formatMessage = %q", got)
+	}
+
+	logger, out := newTestLogger()
+	logger.AddField("max", uint64(math.MaxUint64)).Infof("at %d", int64(math.MinInt64))
+	if want := `at -9223372036854775808, max: "18446744073709551615"`; !strings.HasSuffix(lines(out)[0], want) {
+		t.Errorf("line %q, want suffix %q", lines(out)[0], want)
+	}
+}
+
+func TestFormatMessageBinaryOctal(t *testing.T) {
+	tests := []struct {
+		format string
+		arg    interface{}
+		want   string
+	}{
+		{"%b", 0, "0"},
+		{"%o", 0, "0"},
+		{"%b", 5, "101"},
+		{"%o", 8, "10"},
+		{"%b", -5, "-101"},
+		{"%o", int8(-8), "-10"},
+		{"%o", 0755, "755"},
+		{"%b", uint8(255), "11111111"},
+		{"%o", uint64(math.MaxUint64), "1777777777777777777777"},
+		{"%b", int64(math.MinInt64), "-1" + strings.Repeat("0", 63)},
+		{"mode %o!", 0644, "mode 644!"},
+	}
+	for _, tt := range tests {
+		if got := formatMessage(tt.format, tt.arg); got != tt.want {
+			t.Errorf("formatMessage(%q, %T %v) = %q, want %q", tt.format, tt.arg, tt.arg, got, tt.want)
+		}
+	}
+
+	logger, out := newTestLogger(WithLayout("{msg}"))
+	logger.Infof("flags %b, perm %o", 0, -0644)
+	if got := lines(out)[0]; got != "flags 0, perm -644" {
+		t.Errorf("line %q", got)
+	}
+}
+
+func TestFallbackFormatter(t *testing.T) {
+	ch := make(chan int)
+	fn := func() {}
+	type custom struct{ A int }
+
+	for value, want := range map[interface{}]string{
+		ch:            "<unsupported:chan int>",
+		custom{1}:     "<unsupported:trolog.custom>",
+		complex(1, 2): "<unsupported:complex128>",
+	} {
+		if got := valueToString(value); got != want {
+			t.Errorf("valueToString(%T) = %q, want %q", value, got, want)
+		}
+	}
+	if got := valueToString(fn); got != "<unsupported:func()>" {
+		t.Errorf("valueToString(func) = %q", got)
+	}
+
+	SetFallbackFormatter(func(value interface{}) string { return fmt.Sprintf("%+v", value) })
+	t.Cleanup(func() { SetFallbackFormatter(nil) })
+	logger, out := newTestLogger()
+	logger.AddField("point", custom{7}).Infof("sent to %v", ch)
+	if got := lines(out)[0]; !strings.HasSuffix(got, fmt.Sprintf("sent to %v, point: \"{A:7}\"", ch)) {
+		t.Errorf("line %q, want the values from the fallback formatter", got)
+	}
+	// Supported types do not go through the fallback
+	if got := valueToString(42); got != "42" {
+		t.Errorf("valueToString(42) = %q with a fallback set", got)
+	}
+
+	SetFallbackFormatter(nil)
+	if got := valueToString(custom{1}); got != "<unsupported:trolog.custom>" {
+		t.Errorf("valueToString after reset = %q", got)
+	}
+}
+
+func TestFormatMessageBadArguments(t *testing.T) {
+	tests := []struct {
+		format string
+		args   []interface{}
+		want   string
+	}{
+		{"mask %b", nil, "mask %!b(MISSING)"},
+		{"perm %o", nil, "perm %!o(MISSING)"},
+		{"%s and %d", []interface{}{"a"}, "a and %!d(MISSING)"},
+		{"%v", nil, "%!v(MISSING)"},
+		{"ratio %f", nil, "ratio %!f(MISSING)"},
+		{"ratio %f", []interface{}{3}, "ratio %!f(int=3)"},
+		{"ratio %f", []interface{}{"high"}, "ratio %!f(string=high)"},
+		{"ratio %f", []interface{}{nil}, "ratio %!f(<nil>)"},
+		{"ratio %f", []interface{}{float32(0.5)}, "ratio 0.50"},
+	}
+	for _, tt := range tests {
+		if got := formatMessage(tt.format, tt.args...); got != tt.want {
+			t.Errorf("formatMessage(%q, %v) = %q, want %q", tt.format, tt.args, got, tt.want)
+		}
+	}
+
+	logger, out := newTestLogger(WithLayout("{msg}"))
+	formats := []string{"mask %b", "took %d of %d"}
+	logger.Infof(formats[0])
+	logger.Errorf(formats[1], 1)
+	if got := lines(out); len(got) != 2 || got[0] != "mask %!b(MISSING)" || got[1] != "took 1 of %!d(MISSING)" {
+		t.Errorf("lines = %q", got)
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	webhookQueueSize = 256
+	webhookInterval  = time.Second // minimum time between two requests
+	webhookTimeout   = 10 * time.Second
+)
+
+// webhookSink POSTs batches of entries as a JSON array to a URL from a background goroutine
+type webhookSink struct {
+	url       string
+	minLevel  LogLevel
+	batchSize int
+	client    *http.Client
+
+	mu      sync.RWMutex // guards closed against sends on a closed queue
+	closed  bool
+	queue   chan Entry
+	stop    chan struct{}
+	done    chan struct{}
+	dropped uint64
+}
+
+// WithWebhook POSTs entries at or above minLevel (and the logger's level) to url,
+// e.g. for Slack or PagerDuty style alerting. Trace counts as less severe than
+// Debug here, so only a TraceLevel minimum sends Trace entries. Entries are sent as a JSON array of
+// up to batchSize objects, at most one request per second. When the endpoint is
+// slow or down, entries are dropped and counted rather than blocking the caller.
+func WithWebhook(url string, minLevel LogLevel, batchSize int) Option {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	return withSink(func() sink {
+		s := &webhookSink{
+			url:       url,
+			minLevel:  minLevel,
+			batchSize: batchSize,
+			client:    &http.Client{Timeout: webhookTimeout},
+			queue:     make(chan Entry, webhookQueueSize),
+			stop:      make(chan struct{}),
+			done:      make(chan struct{}),
+		}
+		go s.run()
+		return s
+	})
+}
+
+func (s *webhookSink) write(entry Entry, line []byte) {
+	if !entry.Level.atLeast(s.minLevel) {
+		return
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		atomic.AddUint64(&s.dropped, 1)
+		return
+	}
+	select {
+	case s.queue <- entry:
+	default:
+		atomic.AddUint64(&s.dropped, 1)
+	}
+}
+
+func (s *webhookSink) droppedEntries() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// run batches queued entries and sends them until the queue is closed
+func (s *webhookSink) run() {
+	defer close(s.done)
+
+	var last time.Time
+	for entry := range s.queue {
+		batch := append(make([]Entry, 0, s.batchSize), entry)
+		ready := time.NewTimer(time.Until(last.Add(webhookInterval)))
+		batch = s.collect(batch, ready.C)
+		ready.Stop()
+
+		s.send(batch)
+		last = time.Now()
+	}
+}
+
+// collect adds queued entries to batch until it is full or ready fires. A full
+// batch still waits for ready, unless the sink is closing.
+func (s *webhookSink) collect(batch []Entry, ready <-chan time.Time) []Entry {
+	for len(batch) < s.batchSize {
+		select {
+		case entry, ok := <-s.queue:
+			if !ok {
+				return batch
+			}
+			batch = append(batch, entry)
+		case <-ready:
+			return batch
+		}
+	}
+
+	select {
+	case <-ready:
+	case <-s.stop:
+	}
+	return batch
+}
+
+// send POSTs batch to the webhook, counting the entries as dropped on failure
+func (s *webhookSink) send(batch []Entry) {
+	body := []byte{'['}
+	for i, entry := range batch {
+		if i > 0 {
+			body = append(body, ',')
+		}
+		body = appendJSONMessage(body, &defaultEncoding, entry.Level, entry.Time.Format(time.RFC3339Nano), entry.Message, fieldsFromMap(entry.Fields), nil, entry.ID)
+		body = body[:len(body)-1] // Drop the trailing newline
+	}
+	body = append(body, ']')
+
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		atomic.AddUint64(&s.dropped, uint64(len(batch)))
+		return
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		atomic.AddUint64(&s.dropped, uint64(len(batch)))
+	}
+}
+
+// Close sends the entries still queued without waiting for the rate limit
+func (s *webhookSink) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	close(s.queue)
+	close(s.stop)
+	s.mu.Unlock()
+
+	<-s.done
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2024 Md. Tolha Bin Ashraf
+// All rights reserved.
+// This software is licensed under the MIT License. See the LICENSE file for details.
+
+package trolog
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestWebhook(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		entries []map[string]interface{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		var batch []map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+			t.Errorf("decoding batch: %v", err)
+		}
+		mu.Lock()
+		entries = append(entries, batch...)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	logger := New(WithOutput(io.Discard), WithWebhook(server.URL, ErrorLevel, 10))
+	logger.Info("not alerted")
+	derived := logger.AddField("disk", "sda")
+	derived.Error("disk failing")
+	logger.Panic("out of space")
+	_ = derived.Close()
+	_ = logger.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(entries) != 2 {
+		t.Fatalf("received %d entries, want 2: %v", len(entries), entries)
+	}
+	if entries[0]["msg"] != "disk failing" || entries[0]["level"] != "ERRO" || entries[0]["disk"] != "sda" {
+		t.Errorf("first entry = %v", entries[0])
+	}
+	if entries[1]["msg"] != "out of space" || entries[1]["level"] != "PANI" {
+		t.Errorf("second entry = %v", entries[1])
+	}
+	if d := logger.Drops(); d.Sinks != 0 {
+		t.Errorf("Drops().Sinks = %d, want 0", d.Sinks)
+	}
+}
+
+func TestWebhookDropsOnFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	logger := New(WithOutput(io.Discard), WithWebhook(server.URL, ErrorLevel, 10))
+	logger.Error("lost")
+	logger.Error("lost too")
+	_ = logger.Close()
+	logger.Error("after close")
+
+	if d := logger.Drops(); d.Sinks != 3 {
+		t.Errorf("Drops().Sinks = %d, want 3", d.Sinks)
+	}
+}
+
+func TestWebhookOptionReuse(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	opt := WithWebhook(server.URL, ErrorLevel, 1)
+	a := New(WithOutput(io.Discard), opt)
+	b := New(WithOutput(io.Discard), opt)
+	_ = a.Close()
+	b.Error("sent by b")
+	_ = b.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if requests != 1 {
+		t.Errorf("got %d requests, want 1", requests)
+	}
+}
+
+func TestWebhookSkipsTrace(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []map[string]interface{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch []map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&batch)
+		mu.Lock()
+		received = append(received, batch...)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	logger := New(WithOutput(io.Discard), WithLevel(DebugLevel), WithWebhook(server.URL, ErrorLevel, 10))
+	logger.Trace("trace noise")
+	logger.Debug("debug noise")
+	_ = logger.Close()
+
+	traced := New(WithOutput(io.Discard), WithLevel(DebugLevel), WithWebhook(server.URL, TraceLevel, 10))
+	traced.Trace("wanted trace")
+	_ = traced.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(received) != 1 || received[0]["msg"] != "wanted trace" {
+		t.Errorf("received %v, want only the entry of the TraceLevel webhook", received)
+	}
+}